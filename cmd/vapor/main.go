@@ -151,6 +151,18 @@ func main() {
 	api.GET("/auth/tokens", authService.ListTokens)
 	api.DELETE("/auth/tokens/:id", authService.RevokeToken)
 	api.GET("/auth/tokens/:id", authService.GetToken)
+
+	// Role-based access control routes
+	api.GET("/auth/permissions", authService.GetCurrentPermissions)
+	rbacGroup := api.Group("/auth", auth.Authorize("auth.roles"))
+	rbacGroup.GET("/roles", authService.ListRoles)
+	rbacGroup.POST("/roles", authService.CreateRole)
+	rbacGroup.GET("/roles/:name", authService.GetRole)
+	rbacGroup.PUT("/roles/:name", authService.UpdateRole)
+	rbacGroup.DELETE("/roles/:name", authService.DeleteRole)
+	rbacGroup.GET("/group-mappings", authService.ListGroupMappings)
+	rbacGroup.POST("/group-mappings", authService.CreateGroupMapping)
+	rbacGroup.DELETE("/group-mappings/:group/:role", authService.DeleteGroupMapping)
 	{
 		// Network routes
 		networkService := network.NewService()
@@ -6,9 +6,11 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"os/user"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -25,6 +27,7 @@ type EnhancedService struct {
 	jwtSecret    []byte
 	challenges   map[string]*SSHChallenge // In-memory storage for challenges
 	tokenService *TokenService
+	rbac         *RBACService
 }
 
 // EnhancedLoginRequest supports multiple authentication methods
@@ -41,6 +44,7 @@ type EnhancedLoginResponse struct {
 	Token     string    `json:"token"`
 	ExpiresAt int64     `json:"expires_at"`
 	User      *UserInfo `json:"user"`
+	Roles     []string  `json:"roles,omitempty"`
 }
 
 // UserInfo represents user information
@@ -82,10 +86,16 @@ type VerifyRequest struct {
 // NewEnhancedService creates a new enhanced auth service
 func NewEnhancedService(secret string) *EnhancedService {
 	ts, _ := NewTokenService() // Best effort init
+	rbac, err := NewRBACService()
+	if err != nil {
+		log.Printf("Warning: RBAC store unavailable, falling back to built-in roles: %v", err)
+		rbac = nil
+	}
 	return &EnhancedService{
 		jwtSecret:    []byte(secret),
 		challenges:   make(map[string]*SSHChallenge),
 		tokenService: ts,
+		rbac:         rbac,
 	}
 }
 
@@ -126,11 +136,19 @@ func (s *EnhancedService) EnhancedLogin(c *gin.Context) {
 		return
 	}
 
+	// Resolve roles from Linux group memberships
+	roles := s.resolveRoles(req.Username)
+	if len(roles) == 0 {
+		common.SendError(c, http.StatusForbidden, common.ErrCodeForbidden, "User is not assigned any role")
+		return
+	}
+
 	// Create token
 	expirationTime := time.Now().Add(24 * time.Hour)
 	claims := &Claims{
 		Username: req.Username,
-		Role:     "admin",
+		Role:     roles[0],
+		Roles:    roles,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -149,6 +167,7 @@ func (s *EnhancedService) EnhancedLogin(c *gin.Context) {
 		Token:     tokenString,
 		ExpiresAt: expirationTime.Unix(),
 		User:      userInfo,
+		Roles:     roles,
 	})
 }
 
@@ -237,11 +256,19 @@ func (s *EnhancedService) VerifySSHChallenge(c *gin.Context) {
 		return
 	}
 
+	// Resolve roles from Linux group memberships
+	roles := s.resolveRoles(req.Username)
+	if len(roles) == 0 {
+		common.SendError(c, http.StatusForbidden, common.ErrCodeForbidden, "User is not assigned any role")
+		return
+	}
+
 	// Create token
 	expirationTime := time.Now().Add(24 * time.Hour)
 	claims := &Claims{
 		Username: req.Username,
-		Role:     "admin",
+		Role:     roles[0],
+		Roles:    roles,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -260,6 +287,7 @@ func (s *EnhancedService) VerifySSHChallenge(c *gin.Context) {
 		Token:     tokenString,
 		ExpiresAt: expirationTime.Unix(),
 		User:      userInfo,
+		Roles:     roles,
 	})
 }
 
@@ -302,8 +330,8 @@ func (s *EnhancedService) AuthMiddleware() gin.HandlerFunc {
 				if s.tokenService != nil {
 					apiToken, err := s.tokenService.VerifyToken(tokenToVerify)
 					if err == nil && apiToken != nil {
-						c.Set("username", apiToken.Username)
-						c.Set("role", "admin")
+						s.setAuthContext(c, apiToken.Username, s.resolveRoles(apiToken.Username))
+						setTokenContext(c, apiToken)
 						c.Next()
 						return
 					}
@@ -346,13 +374,42 @@ func (s *EnhancedService) AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Store claims in context
-		c.Set("username", claims.Username)
-		c.Set("role", claims.Role)
+		// Store claims in context. Tokens issued before RBAC was introduced
+		// carry no roles, so resolve them from the user's groups instead.
+		roles := claims.Roles
+		if len(roles) == 0 {
+			roles = s.resolveRoles(claims.Username)
+		}
+		s.setAuthContext(c, claims.Username, roles)
 		c.Next()
 	}
 }
 
+// setAuthContext stores the authenticated identity, roles and resolved
+// permissions in the request context
+func (s *EnhancedService) setAuthContext(c *gin.Context, username string, roles []string) {
+	role := ""
+	if len(roles) > 0 {
+		role = roles[0]
+	}
+	c.Set("username", username)
+	c.Set("role", role)
+	c.Set(ContextKeyRoles, roles)
+	c.Set(ContextKeyPermissions, s.permissionsForRoles(roles))
+}
+
+// setTokenContext stores the API token ID, scopes and restrictions in the
+// request context so that authorization can narrow the owner's permissions
+func setTokenContext(c *gin.Context, token *APIToken) {
+	c.Set(ContextKeyTokenID, token.ID)
+	if len(token.Scopes) > 0 {
+		c.Set(ContextKeyTokenScopes, parsePermissions(token.Scopes))
+	}
+	if !token.Restrictions.IsEmpty() {
+		c.Set(ContextKeyTokenRestrictions, token.Restrictions)
+	}
+}
+
 // RefreshToken handles JWT token refresh
 func (s *EnhancedService) RefreshToken(c *gin.Context) {
 	// Get token from header
@@ -398,6 +455,7 @@ func (s *EnhancedService) RefreshToken(c *gin.Context) {
 					newClaims := &Claims{
 						Username: claims.Username,
 						Role:     claims.Role,
+						Roles:    claims.Roles,
 						RegisteredClaims: jwt.RegisteredClaims{
 							ExpiresAt: jwt.NewNumericDate(newExpirationTime),
 							IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -444,6 +502,7 @@ func (s *EnhancedService) RefreshToken(c *gin.Context) {
 	newClaims := &Claims{
 		Username: claims.Username,
 		Role:     claims.Role,
+		Roles:    claims.Roles,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(newExpirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -468,7 +527,41 @@ func (s *EnhancedService) RefreshToken(c *gin.Context) {
 // Helper methods
 
 func (s *EnhancedService) validatePasswordAuth(username, password string) bool {
-	return authenticateLinuxUser(username, password)
+	if s.rbac == nil {
+		return authenticateLinuxUser(username, password)
+	}
+	// With RBAC enabled any user mapped to a role may log in; the role
+	// check happens after authentication
+	return userExists(username) && authenticateWithPAM(username, password)
+}
+
+// resolveRoles returns the roles of a user based on its Linux groups. When
+// the RBAC store is unavailable sudoers are treated as administrators.
+func (s *EnhancedService) resolveRoles(username string) []string {
+	if s.rbac == nil {
+		if isSudoUser(username) {
+			return []string{RoleAdmin}
+		}
+		return nil
+	}
+	roles, err := s.rbac.RolesForUser(username)
+	if err != nil {
+		log.Printf("Warning: failed to resolve roles for %s: %v", username, err)
+		return nil
+	}
+	return roles
+}
+
+func (s *EnhancedService) permissionsForRoles(roles []string) []Permission {
+	if s.rbac == nil {
+		return builtinPermissions(roles)
+	}
+	perms, err := s.rbac.PermissionsForRoles(roles)
+	if err != nil {
+		log.Printf("Warning: failed to resolve permissions for roles %v: %v", roles, err)
+		return nil
+	}
+	return perms
 }
 
 func (s *EnhancedService) validateSSHKeyAuth(username, privateKeyStr, passphrase string) bool {
@@ -627,8 +720,10 @@ func (s *EnhancedService) cleanupExpiredChallenges() {
 // Token Management Handlers
 
 type CreateTokenRequest struct {
-	Name      string     `json:"name" binding:"required"`
-	ExpiresAt *time.Time `json:"expires_at"`
+	Name         string             `json:"name" binding:"required"`
+	ExpiresAt    *time.Time         `json:"expires_at"`
+	Scopes       []string           `json:"scopes"`
+	Restrictions *TokenRestrictions `json:"restrictions"`
 }
 
 func (s *EnhancedService) CreateToken(c *gin.Context) {
@@ -644,13 +739,25 @@ func (s *EnhancedService) CreateToken(c *gin.Context) {
 		return
 	}
 
+	// A scoped token must not be able to mint a token with wider access
+	_, scoped := c.Get(ContextKeyTokenScopes)
+	_, restricted := c.Get(ContextKeyTokenRestrictions)
+	if scoped || restricted {
+		common.SendError(c, http.StatusForbidden, common.ErrCodeForbidden, "Scoped API tokens cannot create tokens")
+		return
+	}
+
 	if s.tokenService == nil {
 		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "Token service not available")
 		return
 	}
 
-	apiToken, rawToken, err := s.tokenService.CreateToken(username, req.Name, req.ExpiresAt)
+	apiToken, rawToken, err := s.tokenService.CreateToken(username, req.Name, req.ExpiresAt, req.Scopes, req.Restrictions)
 	if err != nil {
+		if errors.Is(err, ErrInvalidPermission) || errors.Is(err, path.ErrBadPattern) {
+			common.SendError(c, http.StatusBadRequest, common.ErrCodeValidation, "Invalid token scope", err.Error())
+			return
+		}
 		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "Failed to create token", err.Error())
 		return
 	}
@@ -722,6 +829,11 @@ func (s *EnhancedService) GetToken(c *gin.Context) {
 	common.SendSuccess(c, token)
 }
 
+// GetRBACService returns the RBAC service instance
+func (s *EnhancedService) GetRBACService() *RBACService {
+	return s.rbac
+}
+
 // GetTokenService returns the token service instance
 func (s *EnhancedService) GetTokenService() *TokenService {
 	return s.tokenService
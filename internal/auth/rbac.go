@@ -0,0 +1,640 @@
+package auth
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/user"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/awanio/vapor/internal/common"
+	"github.com/awanio/vapor/internal/database"
+	"github.com/gin-gonic/gin"
+)
+
+// Permission verbs
+const (
+	VerbRead   = "read"
+	VerbWrite  = "write"
+	VerbDelete = "delete"
+	VerbAction = "action"
+	VerbAll    = "*"
+)
+
+// Built-in role names
+const (
+	RoleAdmin    = "admin"
+	RoleOperator = "operator"
+	RoleViewer   = "viewer"
+)
+
+// Context keys set by the authentication middleware
+const (
+	ContextKeyRoles       = "roles"
+	ContextKeyPermissions = "permissions"
+)
+
+var (
+	// ErrRoleNotFound is returned when a role does not exist
+	ErrRoleNotFound = errors.New("role not found")
+	// ErrRoleExists is returned when creating a role that already exists
+	ErrRoleExists = errors.New("role already exists")
+	// ErrBuiltinRole is returned when trying to modify a built-in role
+	ErrBuiltinRole = errors.New("built-in roles cannot be modified")
+	// ErrInvalidPermission is returned when a permission string cannot be parsed
+	ErrInvalidPermission = errors.New("invalid permission")
+)
+
+// Permission represents a single "resource:verb" grant. A leading "!"
+// turns the permission into an explicit deny, which takes precedence over
+// any grant.
+type Permission struct {
+	Resource string `json:"resource"`
+	Verb     string `json:"verb"`
+	Deny     bool   `json:"deny,omitempty"`
+}
+
+// ParsePermission parses a permission string such as "kubernetes.secrets:read",
+// "virtualization.*:*" or "!kubernetes.secrets:*"
+func ParsePermission(s string) (Permission, error) {
+	var p Permission
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "!") {
+		p.Deny = true
+		s = s[1:]
+	}
+
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Permission{}, fmt.Errorf("%w %q: expected resource:verb", ErrInvalidPermission, s)
+	}
+	p.Resource = parts[0]
+	p.Verb = parts[1]
+	return p, nil
+}
+
+// String returns the permission in "resource:verb" form
+func (p Permission) String() string {
+	s := p.Resource + ":" + p.Verb
+	if p.Deny {
+		return "!" + s
+	}
+	return s
+}
+
+// Matches reports whether p covers the requested resource and verb.
+// "*" matches everything, and a resource ending in ".*" matches every
+// resource below that prefix.
+func (p Permission) Matches(resource, verb string) bool {
+	if p.Verb != VerbAll && p.Verb != verb {
+		return false
+	}
+	if p.Resource == "*" || p.Resource == resource {
+		return true
+	}
+	if strings.HasSuffix(p.Resource, ".*") {
+		prefix := strings.TrimSuffix(p.Resource, "*")
+		return strings.HasPrefix(resource, prefix)
+	}
+	return false
+}
+
+// HasPermission reports whether the permission set allows verb on resource
+func HasPermission(perms []Permission, resource, verb string) bool {
+	allowed := false
+	for _, p := range perms {
+		if !p.Matches(resource, verb) {
+			continue
+		}
+		if p.Deny {
+			return false
+		}
+		allowed = true
+	}
+	return allowed
+}
+
+// Role represents a named set of permissions
+type Role struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	BuiltIn     bool      `json:"built_in"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// GroupRoleMapping maps a Linux group to a role
+type GroupRoleMapping struct {
+	Group     string    `json:"group"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BuiltinRoles are always present and cannot be modified through the API
+var BuiltinRoles = []Role{
+	{
+		Name:        RoleAdmin,
+		Description: "Full access to all resources",
+		Permissions: []string{"*:*"},
+	},
+	{
+		Name:        RoleOperator,
+		Description: "Read access to all resources and power actions on virtual machines",
+		Permissions: []string{
+			"*:read",
+			"virtualization.computes:action",
+			"!kubernetes.secrets:*",
+			"!auth.roles:*",
+		},
+	},
+	{
+		Name:        RoleViewer,
+		Description: "Read-only access to all resources except secrets",
+		Permissions: []string{
+			"*:read",
+			"!kubernetes.secrets:*",
+			"!auth.roles:*",
+		},
+	},
+}
+
+// RBACService manages roles, group mappings and permission resolution
+type RBACService struct {
+	db    *database.DB
+	mu    sync.RWMutex
+	cache map[string][]Permission
+}
+
+// NewRBACService creates a new RBACService and ensures the built-in roles exist
+func NewRBACService() (*RBACService, error) {
+	db, err := database.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	s := &RBACService{
+		db:    db,
+		cache: make(map[string][]Permission),
+	}
+	if err := s.ensureBuiltinRoles(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *RBACService) ensureBuiltinRoles() error {
+	return s.db.Transaction(func(tx *sql.Tx) error {
+		for _, role := range BuiltinRoles {
+			perms, err := json.Marshal(role.Permissions)
+			if err != nil {
+				return err
+			}
+			_, err = tx.Exec(`
+INSERT INTO rbac_roles (name, description, permissions, built_in, created_at, updated_at)
+VALUES (?, ?, ?, 1, ?, ?)
+ON CONFLICT(name) DO UPDATE SET
+	description = excluded.description,
+	permissions = excluded.permissions,
+	built_in = 1
+`, role.Name, role.Description, string(perms), time.Now(), time.Now())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListRoles lists all roles
+func (s *RBACService) ListRoles() ([]Role, error) {
+	var roles []Role
+	err := s.db.Transaction(func(tx *sql.Tx) error {
+		rows, err := tx.Query("SELECT name, description, permissions, built_in, created_at, updated_at FROM rbac_roles ORDER BY built_in DESC, name")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			role, err := scanRole(rows)
+			if err != nil {
+				return err
+			}
+			roles = append(roles, *role)
+		}
+		return rows.Err()
+	})
+	return roles, err
+}
+
+// GetRole gets a role by name
+func (s *RBACService) GetRole(name string) (*Role, error) {
+	var role *Role
+	err := s.db.Transaction(func(tx *sql.Tx) error {
+		row := tx.QueryRow("SELECT name, description, permissions, built_in, created_at, updated_at FROM rbac_roles WHERE name = ?", name)
+		var err error
+		role, err = scanRole(row)
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// CreateRole creates a custom role
+func (s *RBACService) CreateRole(name, description string, permissions []string) (*Role, error) {
+	if err := validatePermissions(permissions); err != nil {
+		return nil, err
+	}
+	perms, err := json.Marshal(permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = s.db.Transaction(func(tx *sql.Tx) error {
+		var count int
+		if err := tx.QueryRow("SELECT count(*) FROM rbac_roles WHERE name = ?", name).Scan(&count); err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrRoleExists
+		}
+		_, err := tx.Exec(`
+INSERT INTO rbac_roles (name, description, permissions, built_in, created_at, updated_at)
+VALUES (?, ?, ?, 0, ?, ?)
+`, name, description, string(perms), now, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Role{
+		Name:        name,
+		Description: description,
+		Permissions: permissions,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// UpdateRole replaces the description and permissions of a custom role
+func (s *RBACService) UpdateRole(name, description string, permissions []string) (*Role, error) {
+	if err := validatePermissions(permissions); err != nil {
+		return nil, err
+	}
+	perms, err := json.Marshal(permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *sql.Tx) error {
+		var builtIn bool
+		err := tx.QueryRow("SELECT built_in FROM rbac_roles WHERE name = ?", name).Scan(&builtIn)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if builtIn {
+			return ErrBuiltinRole
+		}
+		_, err = tx.Exec("UPDATE rbac_roles SET description = ?, permissions = ?, updated_at = ? WHERE name = ?",
+			description, string(perms), time.Now(), name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCache()
+	return s.GetRole(name)
+}
+
+// DeleteRole deletes a custom role and its group mappings
+func (s *RBACService) DeleteRole(name string) error {
+	err := s.db.Transaction(func(tx *sql.Tx) error {
+		var builtIn bool
+		err := tx.QueryRow("SELECT built_in FROM rbac_roles WHERE name = ?", name).Scan(&builtIn)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if builtIn {
+			return ErrBuiltinRole
+		}
+		if _, err := tx.Exec("DELETE FROM rbac_group_roles WHERE role_name = ?", name); err != nil {
+			return err
+		}
+		_, err = tx.Exec("DELETE FROM rbac_roles WHERE name = ?", name)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateCache()
+	return nil
+}
+
+// ListGroupMappings lists all Linux group to role mappings
+func (s *RBACService) ListGroupMappings() ([]GroupRoleMapping, error) {
+	var mappings []GroupRoleMapping
+	err := s.db.Transaction(func(tx *sql.Tx) error {
+		rows, err := tx.Query("SELECT group_name, role_name, created_at FROM rbac_group_roles ORDER BY group_name, role_name")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var m GroupRoleMapping
+			if err := rows.Scan(&m.Group, &m.Role, &m.CreatedAt); err != nil {
+				return err
+			}
+			mappings = append(mappings, m)
+		}
+		return rows.Err()
+	})
+	return mappings, err
+}
+
+// AddGroupMapping maps a Linux group to a role
+func (s *RBACService) AddGroupMapping(group, role string) (*GroupRoleMapping, error) {
+	now := time.Now()
+	err := s.db.Transaction(func(tx *sql.Tx) error {
+		var count int
+		if err := tx.QueryRow("SELECT count(*) FROM rbac_roles WHERE name = ?", role).Scan(&count); err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrRoleNotFound
+		}
+		_, err := tx.Exec("INSERT OR IGNORE INTO rbac_group_roles (group_name, role_name, created_at) VALUES (?, ?, ?)", group, role, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &GroupRoleMapping{Group: group, Role: role, CreatedAt: now}, nil
+}
+
+// DeleteGroupMapping removes a Linux group to role mapping
+func (s *RBACService) DeleteGroupMapping(group, role string) error {
+	return s.db.Transaction(func(tx *sql.Tx) error {
+		res, err := tx.Exec("DELETE FROM rbac_group_roles WHERE group_name = ? AND role_name = ?", group, role)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return errors.New("group mapping not found")
+		}
+		return nil
+	})
+}
+
+// RolesForGroups returns the roles mapped to any of the given Linux groups
+func (s *RBACService) RolesForGroups(groups []string) ([]string, error) {
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(groups)), ",")
+	args := make([]interface{}, len(groups))
+	for i, g := range groups {
+		args[i] = g
+	}
+
+	var roles []string
+	err := s.db.Transaction(func(tx *sql.Tx) error {
+		rows, err := tx.Query("SELECT DISTINCT role_name FROM rbac_group_roles WHERE group_name IN ("+placeholders+") ORDER BY role_name", args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var role string
+			if err := rows.Scan(&role); err != nil {
+				return err
+			}
+			roles = append(roles, role)
+		}
+		return rows.Err()
+	})
+	return roles, err
+}
+
+// RolesForUser resolves the roles of a Linux user from its group memberships
+func (s *RBACService) RolesForUser(username string) ([]string, error) {
+	groups, err := userGroups(username)
+	if err != nil {
+		return nil, err
+	}
+	return s.RolesForGroups(groups)
+}
+
+// PermissionsForRoles returns the combined permissions of the given roles
+func (s *RBACService) PermissionsForRoles(roles []string) ([]Permission, error) {
+	var perms []Permission
+	for _, name := range roles {
+		rolePerms, err := s.rolePermissions(name)
+		if err != nil {
+			if errors.Is(err, ErrRoleNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		perms = append(perms, rolePerms...)
+	}
+	return perms, nil
+}
+
+func (s *RBACService) rolePermissions(name string) ([]Permission, error) {
+	s.mu.RLock()
+	perms, ok := s.cache[name]
+	s.mu.RUnlock()
+	if ok {
+		return perms, nil
+	}
+
+	role, err := s.GetRole(name)
+	if err != nil {
+		return nil, err
+	}
+	perms = parsePermissions(role.Permissions)
+
+	s.mu.Lock()
+	s.cache[name] = perms
+	s.mu.Unlock()
+	return perms, nil
+}
+
+func (s *RBACService) invalidateCache() {
+	s.mu.Lock()
+	s.cache = make(map[string][]Permission)
+	s.mu.Unlock()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRole(row rowScanner) (*Role, error) {
+	var role Role
+	var perms string
+	if err := row.Scan(&role.Name, &role.Description, &perms, &role.BuiltIn, &role.CreatedAt, &role.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(perms), &role.Permissions); err != nil {
+		return nil, fmt.Errorf("invalid permissions for role %s: %w", role.Name, err)
+	}
+	return &role, nil
+}
+
+func validatePermissions(permissions []string) error {
+	for _, p := range permissions {
+		if _, err := ParsePermission(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parsePermissions(permissions []string) []Permission {
+	perms := make([]Permission, 0, len(permissions))
+	for _, s := range permissions {
+		p, err := ParsePermission(s)
+		if err != nil {
+			continue
+		}
+		perms = append(perms, p)
+	}
+	return perms
+}
+
+// builtinPermissions returns the permissions of a built-in role without
+// touching the database. It is used when the RBAC store is unavailable.
+func builtinPermissions(roles []string) []Permission {
+	var perms []Permission
+	for _, name := range roles {
+		for _, role := range BuiltinRoles {
+			if role.Name == name {
+				perms = append(perms, parsePermissions(role.Permissions)...)
+			}
+		}
+	}
+	return perms
+}
+
+// userGroups returns the names of all groups the user belongs to
+func userGroups(username string) ([]string, error) {
+	u, err := user.Lookup(username)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs, err := u.GroupIds()
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []string
+	for _, gid := range groupIDs {
+		group, err := user.LookupGroupId(gid)
+		if err != nil {
+			continue
+		}
+		groups = append(groups, group.Name)
+	}
+	sort.Strings(groups)
+	return groups, nil
+}
+
+// verbForMethod maps an HTTP method to a permission verb
+func verbForMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return VerbRead
+	case http.MethodDelete:
+		return VerbDelete
+	default:
+		return VerbWrite
+	}
+}
+
+// RequirePermission checks that the authenticated user holds verb on resource
+func RequirePermission(resource, verb string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ok, reason := authorizeContext(c, resource, verb); !ok {
+			common.SendError(c, http.StatusForbidden, common.ErrCodeForbidden, "Insufficient permissions", reason)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// Authorize checks that the authenticated user may access resource, deriving
+// the verb from the HTTP method (GET is read, DELETE is delete, anything
+// else is write)
+func Authorize(resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		verb := verbForMethod(c.Request.Method)
+		if ok, reason := authorizeContext(c, resource, verb); !ok {
+			common.SendError(c, http.StatusForbidden, common.ErrCodeForbidden, "Insufficient permissions", reason)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// authorizeContext checks the caller's role permissions and, for API tokens,
+// the token scopes and resource restrictions. The returned reason explains a
+// denial.
+func authorizeContext(c *gin.Context, resource, verb string) (bool, string) {
+	required := fmt.Sprintf("requires %s:%s", resource, verb)
+	if !contextHasPermission(c, resource, verb) {
+		return false, required
+	}
+
+	if value, ok := c.Get(ContextKeyTokenScopes); ok {
+		if scopes, ok := value.([]Permission); ok && len(scopes) > 0 && !HasPermission(scopes, resource, verb) {
+			return false, "token scope " + required
+		}
+	}
+
+	if value, ok := c.Get(ContextKeyTokenRestrictions); ok {
+		if restrictions, ok := value.(*TokenRestrictions); ok {
+			return restrictionsAllow(c, restrictions, resource, verb)
+		}
+	}
+
+	return true, ""
+}
+
+func contextHasPermission(c *gin.Context, resource, verb string) bool {
+	value, exists := c.Get(ContextKeyPermissions)
+	if !exists {
+		return false
+	}
+	perms, ok := value.([]Permission)
+	if !ok {
+		return false
+	}
+	return HasPermission(perms, resource, verb)
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/awanio/vapor/internal/common"
+	"github.com/gin-gonic/gin"
+)
+
+// Role Management Handlers
+
+// RoleRequest is the request body for creating or updating a role
+type RoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// GroupMappingRequest is the request body for mapping a Linux group to a role
+type GroupMappingRequest struct {
+	Group string `json:"group" binding:"required"`
+	Role  string `json:"role" binding:"required"`
+}
+
+// CurrentPermissionsResponse describes the permissions of the caller
+type CurrentPermissionsResponse struct {
+	Username    string   `json:"username"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// GetCurrentPermissions returns the roles and permissions of the authenticated user
+func (s *EnhancedService) GetCurrentPermissions(c *gin.Context) {
+	resp := CurrentPermissionsResponse{
+		Username:    c.GetString("username"),
+		Roles:       []string{},
+		Permissions: []string{},
+	}
+	if roles, ok := c.Get(ContextKeyRoles); ok {
+		resp.Roles = append(resp.Roles, roles.([]string)...)
+	}
+	if perms, ok := c.Get(ContextKeyPermissions); ok {
+		for _, p := range perms.([]Permission) {
+			resp.Permissions = append(resp.Permissions, p.String())
+		}
+	}
+	common.SendSuccess(c, resp)
+}
+
+func (s *EnhancedService) ListRoles(c *gin.Context) {
+	if s.rbac == nil {
+		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "RBAC service not available")
+		return
+	}
+
+	roles, err := s.rbac.ListRoles()
+	if err != nil {
+		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "Failed to list roles", err.Error())
+		return
+	}
+
+	common.SendSuccess(c, roles)
+}
+
+func (s *EnhancedService) GetRole(c *gin.Context) {
+	if s.rbac == nil {
+		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "RBAC service not available")
+		return
+	}
+
+	role, err := s.rbac.GetRole(c.Param("name"))
+	if err != nil {
+		sendRoleError(c, "Failed to get role", err)
+		return
+	}
+
+	common.SendSuccess(c, role)
+}
+
+func (s *EnhancedService) CreateRole(c *gin.Context) {
+	var req RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.SendError(c, http.StatusBadRequest, common.ErrCodeValidation, "Invalid request", err.Error())
+		return
+	}
+	if req.Name == "" {
+		common.SendError(c, http.StatusBadRequest, common.ErrCodeValidation, "Role name is required")
+		return
+	}
+
+	if s.rbac == nil {
+		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "RBAC service not available")
+		return
+	}
+
+	role, err := s.rbac.CreateRole(req.Name, req.Description, req.Permissions)
+	if err != nil {
+		sendRoleError(c, "Failed to create role", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, common.SuccessResponse(role))
+}
+
+func (s *EnhancedService) UpdateRole(c *gin.Context) {
+	var req RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.SendError(c, http.StatusBadRequest, common.ErrCodeValidation, "Invalid request", err.Error())
+		return
+	}
+
+	if s.rbac == nil {
+		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "RBAC service not available")
+		return
+	}
+
+	role, err := s.rbac.UpdateRole(c.Param("name"), req.Description, req.Permissions)
+	if err != nil {
+		sendRoleError(c, "Failed to update role", err)
+		return
+	}
+
+	common.SendSuccess(c, role)
+}
+
+func (s *EnhancedService) DeleteRole(c *gin.Context) {
+	if s.rbac == nil {
+		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "RBAC service not available")
+		return
+	}
+
+	if err := s.rbac.DeleteRole(c.Param("name")); err != nil {
+		sendRoleError(c, "Failed to delete role", err)
+		return
+	}
+
+	common.SendSuccess(c, gin.H{"message": "Role deleted"})
+}
+
+func (s *EnhancedService) ListGroupMappings(c *gin.Context) {
+	if s.rbac == nil {
+		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "RBAC service not available")
+		return
+	}
+
+	mappings, err := s.rbac.ListGroupMappings()
+	if err != nil {
+		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "Failed to list group mappings", err.Error())
+		return
+	}
+
+	common.SendSuccess(c, mappings)
+}
+
+func (s *EnhancedService) CreateGroupMapping(c *gin.Context) {
+	var req GroupMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.SendError(c, http.StatusBadRequest, common.ErrCodeValidation, "Invalid request", err.Error())
+		return
+	}
+
+	if s.rbac == nil {
+		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "RBAC service not available")
+		return
+	}
+
+	mapping, err := s.rbac.AddGroupMapping(req.Group, req.Role)
+	if err != nil {
+		sendRoleError(c, "Failed to create group mapping", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, common.SuccessResponse(mapping))
+}
+
+func (s *EnhancedService) DeleteGroupMapping(c *gin.Context) {
+	if s.rbac == nil {
+		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, "RBAC service not available")
+		return
+	}
+
+	if err := s.rbac.DeleteGroupMapping(c.Param("group"), c.Param("role")); err != nil {
+		common.SendError(c, http.StatusNotFound, common.ErrCodeNotFound, "Group mapping not found", err.Error())
+		return
+	}
+
+	common.SendSuccess(c, gin.H{"message": "Group mapping deleted"})
+}
+
+// sendRoleError maps RBAC errors to HTTP responses
+func sendRoleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, ErrRoleNotFound):
+		common.SendError(c, http.StatusNotFound, common.ErrCodeNotFound, message, err.Error())
+	case errors.Is(err, ErrRoleExists):
+		common.SendError(c, http.StatusConflict, common.ErrCodeConflict, message, err.Error())
+	case errors.Is(err, ErrBuiltinRole):
+		common.SendError(c, http.StatusForbidden, common.ErrCodeForbidden, message, err.Error())
+	case errors.Is(err, ErrInvalidPermission):
+		common.SendError(c, http.StatusBadRequest, common.ErrCodeValidation, message, err.Error())
+	default:
+		common.SendError(c, http.StatusInternalServerError, common.ErrCodeInternal, message, err.Error())
+	}
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePermission(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Permission
+		wantErr bool
+	}{
+		{input: "kubernetes.secrets:read", want: Permission{Resource: "kubernetes.secrets", Verb: "read"}},
+		{input: "!kubernetes.secrets:*", want: Permission{Resource: "kubernetes.secrets", Verb: "*", Deny: true}},
+		{input: "virtualization.*:action", want: Permission{Resource: "virtualization.*", Verb: "action"}},
+		{input: "kubernetes.secrets", wantErr: true},
+		{input: ":read", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePermission(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPermission)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.input, got.String())
+		})
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	operator := builtinPermissions([]string{RoleOperator})
+	viewer := builtinPermissions([]string{RoleViewer})
+	admin := builtinPermissions([]string{RoleAdmin})
+
+	tests := []struct {
+		name     string
+		perms    []Permission
+		resource string
+		verb     string
+		want     bool
+	}{
+		{"admin can delete storage pools", admin, "virtualization.storages", VerbDelete, true},
+		{"operator can list VMs", operator, "virtualization.computes", VerbRead, true},
+		{"operator can restart VMs", operator, "virtualization.computes", VerbAction, true},
+		{"operator cannot delete VMs", operator, "virtualization.computes", VerbDelete, false},
+		{"operator cannot delete storage pools", operator, "virtualization.storages", VerbDelete, false},
+		{"operator cannot read secrets", operator, "kubernetes.secrets", VerbRead, false},
+		{"viewer cannot restart VMs", viewer, "virtualization.computes", VerbAction, false},
+		{"no permissions", nil, "system", VerbRead, false},
+		{
+			"prefix wildcard",
+			parsePermissions([]string{"virtualization.*:read"}),
+			"virtualization.networks", VerbRead, true,
+		},
+		{
+			"prefix wildcard does not match other groups",
+			parsePermissions([]string{"virtualization.*:read"}),
+			"kubernetes.pods", VerbRead, false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, HasPermission(tt.perms, tt.resource, tt.verb))
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		method     string
+		roles      []string
+		wantStatus int
+	}{
+		{"viewer GET", http.MethodGet, []string{RoleViewer}, http.StatusOK},
+		{"viewer DELETE", http.MethodDelete, []string{RoleViewer}, http.StatusForbidden},
+		{"admin DELETE", http.MethodDelete, []string{RoleAdmin}, http.StatusOK},
+		{"unauthenticated", http.MethodGet, nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.Use(func(c *gin.Context) {
+				if tt.roles != nil {
+					c.Set(ContextKeyPermissions, builtinPermissions(tt.roles))
+				}
+				c.Next()
+			})
+			router.Handle(tt.method, "/pools", Authorize("virtualization.storages"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/pools", nil)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
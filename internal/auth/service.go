@@ -18,8 +18,9 @@ type Service struct {
 
 // Claims represents JWT claims
 type Claims struct {
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	Username string   `json:"username"`
+	Role     string   `json:"role"`
+	Roles    []string `json:"roles,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -233,6 +234,14 @@ func (s *Service) RefreshToken(c *gin.Context) {
 func RequireRole(requiredRole string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		role, exists := c.Get("role")
+		if roles, ok := c.Get(ContextKeyRoles); ok {
+			for _, r := range roles.([]string) {
+				if r == requiredRole {
+					exists, role = true, r
+					break
+				}
+			}
+		}
 		if !exists || role != requiredRole {
 			common.SendError(c, http.StatusForbidden, common.ErrCodeForbidden, "Insufficient permissions")
 			c.Abort()
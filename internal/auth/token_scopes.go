@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Context keys set by the authentication middleware for API tokens
+const (
+	ContextKeyTokenID           = "token_id"
+	ContextKeyTokenScopes       = "token_scopes"
+	ContextKeyTokenRestrictions = "token_restrictions"
+)
+
+// Token scope presets
+const (
+	ScopeReadOnly    = "read-only"
+	ScopeVMLifecycle = "vm-lifecycle"
+	ScopeAnsible     = "ansible"
+)
+
+// ScopePresets maps preset scope names to the permissions they grant
+var ScopePresets = map[string][]string{
+	ScopeReadOnly: {
+		"*:read",
+	},
+	ScopeVMLifecycle: {
+		"virtualization.computes:read",
+		"virtualization.computes:action",
+		"virtualization.snapshots:read",
+	},
+	ScopeAnsible: {
+		"ansible.*:*",
+	},
+}
+
+// TokenRestrictions limits an API token to specific resources. Requests that
+// target a Kubernetes namespace or a virtual machine outside the allowed sets
+// are rejected; requests that do not name a namespace or VM are only allowed
+// to read.
+type TokenRestrictions struct {
+	Namespaces []string `json:"namespaces,omitempty"`
+	VMPatterns []string `json:"vm_patterns,omitempty"`
+}
+
+// IsEmpty reports whether no restriction is set
+func (r *TokenRestrictions) IsEmpty() bool {
+	return r == nil || (len(r.Namespaces) == 0 && len(r.VMPatterns) == 0)
+}
+
+// ExpandScopes resolves preset names and validates permission strings. The
+// result is sorted and free of duplicates.
+func ExpandScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool)
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" {
+			continue
+		}
+		if preset, ok := ScopePresets[scope]; ok {
+			for _, p := range preset {
+				seen[p] = true
+			}
+			continue
+		}
+		if _, err := ParsePermission(scope); err != nil {
+			return nil, fmt.Errorf("unknown scope %q: %w", scope, err)
+		}
+		seen[scope] = true
+	}
+
+	expanded := make([]string, 0, len(seen))
+	for p := range seen {
+		expanded = append(expanded, p)
+	}
+	sort.Strings(expanded)
+	return expanded, nil
+}
+
+func validateRestrictions(r *TokenRestrictions) error {
+	if r == nil {
+		return nil
+	}
+	for _, pattern := range r.VMPatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid VM pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// IsScoped reports whether the token is limited by scopes or restrictions.
+// Tokens without either act with the full permissions of their owner.
+func (t *APIToken) IsScoped() bool {
+	return len(t.Scopes) > 0 || !t.Restrictions.IsEmpty()
+}
+
+// restrictionsAllow checks the request's namespace and VM path parameters
+// against the token restrictions
+func restrictionsAllow(c *gin.Context, r *TokenRestrictions, resource, verb string) (bool, string) {
+	if r.IsEmpty() {
+		return true, ""
+	}
+
+	if len(r.Namespaces) > 0 && strings.HasPrefix(resource, "kubernetes.") {
+		namespace := c.Param("namespace")
+		if namespace == "" {
+			if verb != VerbRead {
+				return false, "token is restricted to specific namespaces"
+			}
+		} else if !containsString(r.Namespaces, namespace) {
+			return false, fmt.Sprintf("token is not allowed to access namespace %s", namespace)
+		}
+	}
+
+	if len(r.VMPatterns) > 0 && isVMResource(resource) {
+		vm := c.Param("id")
+		if vm == "" {
+			if verb != VerbRead {
+				return false, "token is restricted to specific virtual machines"
+			}
+		} else if !matchesAnyPattern(r.VMPatterns, vm) {
+			return false, fmt.Sprintf("token is not allowed to access virtual machine %s", vm)
+		}
+	}
+
+	return true, ""
+}
+
+// isVMResource reports whether resource is addressed by a VM in the :id
+// path parameter
+func isVMResource(resource string) bool {
+	switch resource {
+	case "virtualization.computes", "virtualization.consoles", "virtualization.snapshots", "virtualization.backups":
+		return true
+	}
+	return false
+}
+
+func matchesAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
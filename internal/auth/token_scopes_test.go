@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandScopes(t *testing.T) {
+	scopes, err := ExpandScopes([]string{ScopeVMLifecycle, "virtualization.computes:read", " "})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"virtualization.computes:action",
+		"virtualization.computes:read",
+		"virtualization.snapshots:read",
+	}, scopes)
+
+	_, err = ExpandScopes([]string{"everything"})
+	assert.ErrorIs(t, err, ErrInvalidPermission)
+}
+
+func TestScopedTokenAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ciToken := &APIToken{
+		ID:           "ci",
+		Scopes:       ScopePresets[ScopeVMLifecycle],
+		Restrictions: &TokenRestrictions{VMPatterns: []string{"test-*"}},
+	}
+	nsToken := &APIToken{
+		ID:           "ns",
+		Scopes:       ScopePresets[ScopeReadOnly],
+		Restrictions: &TokenRestrictions{Namespaces: []string{"dev"}},
+	}
+
+	tests := []struct {
+		name       string
+		token      *APIToken
+		method     string
+		route      string
+		resource   string
+		path       string
+		wantStatus int
+	}{
+		{"start matching VM", ciToken, http.MethodPost, "/computes/:id/action", "virtualization.computes", "/computes/test-42/action", http.StatusOK},
+		{"start other VM", ciToken, http.MethodPost, "/computes/:id/action", "virtualization.computes", "/computes/prod-db/action", http.StatusForbidden},
+		{"list VMs", ciToken, http.MethodGet, "/computes", "virtualization.computes", "/computes", http.StatusOK},
+		{"destroy RAID", ciToken, http.MethodPost, "/storage/raid/destroy", "storage.raid", "/storage/raid/destroy", http.StatusForbidden},
+		{"read allowed namespace", nsToken, http.MethodGet, "/pods/:namespace", "kubernetes.pods", "/pods/dev", http.StatusOK},
+		{"read other namespace", nsToken, http.MethodGet, "/pods/:namespace", "kubernetes.pods", "/pods/kube-system", http.StatusForbidden},
+		{"write with read-only scope", nsToken, http.MethodDelete, "/pods/:namespace", "kubernetes.pods", "/pods/dev", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+			router.Use(func(c *gin.Context) {
+				c.Set(ContextKeyPermissions, builtinPermissions([]string{RoleAdmin}))
+				setTokenContext(c, tt.token)
+				c.Next()
+			})
+			handler := Authorize(tt.resource)
+			if tt.route == "/computes/:id/action" {
+				handler = RequirePermission(tt.resource, VerbAction)
+			}
+			router.Handle(tt.method, tt.route, handler, func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -21,6 +22,34 @@ type APIToken struct {
 	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
 	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
 	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	// Scopes limits the token to these permissions on top of the owner's
+	// roles. An empty list grants the owner's full permissions.
+	Scopes       []string           `json:"scopes"`
+	Restrictions *TokenRestrictions `json:"restrictions,omitempty"`
+}
+
+const tokenColumns = "id, name, username, created_at, expires_at, revoked_at, last_used_at, scopes, restrictions"
+
+func scanToken(row rowScanner) (*APIToken, error) {
+	var t APIToken
+	var scopes, restrictions sql.NullString
+	if err := row.Scan(&t.ID, &t.Name, &t.Username, &t.CreatedAt, &t.ExpiresAt, &t.RevokedAt, &t.LastUsedAt, &scopes, &restrictions); err != nil {
+		return nil, err
+	}
+
+	t.Scopes = []string{}
+	if scopes.Valid && scopes.String != "" {
+		if err := json.Unmarshal([]byte(scopes.String), &t.Scopes); err != nil {
+			return nil, err
+		}
+	}
+	if restrictions.Valid && restrictions.String != "" {
+		t.Restrictions = &TokenRestrictions{}
+		if err := json.Unmarshal([]byte(restrictions.String), t.Restrictions); err != nil {
+			return nil, err
+		}
+	}
+	return &t, nil
 }
 
 // TokenService handles API token operations
@@ -49,8 +78,35 @@ func GenerateToken() (string, string, error) {
 	return token, tokenHash, nil
 }
 
-// CreateToken creates a new API token for a user
-func (s *TokenService) CreateToken(username, name string, expiresAt *time.Time) (*APIToken, string, error) {
+// CreateToken creates a new API token for a user. Scopes may contain preset
+// names (see ScopePresets) or "resource:verb" permissions; restrictions
+// limit the token to specific namespaces or virtual machines.
+func (s *TokenService) CreateToken(username, name string, expiresAt *time.Time, scopes []string, restrictions *TokenRestrictions) (*APIToken, string, error) {
+	expanded, err := ExpandScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := validateRestrictions(restrictions); err != nil {
+		return nil, "", err
+	}
+	if restrictions.IsEmpty() {
+		restrictions = nil
+	}
+
+	scopesJSON, err := json.Marshal(expanded)
+	if err != nil {
+		return nil, "", err
+	}
+	var restrictionsJSON *string
+	if restrictions != nil {
+		data, err := json.Marshal(restrictions)
+		if err != nil {
+			return nil, "", err
+		}
+		str := string(data)
+		restrictionsJSON = &str
+	}
+
 	token, tokenHash, err := GenerateToken()
 	if err != nil {
 		return nil, "", err
@@ -60,20 +116,22 @@ func (s *TokenService) CreateToken(username, name string, expiresAt *time.Time)
 	now := time.Now()
 
 	apiToken := &APIToken{
-		ID:        id,
-		Name:      name,
-		Username:  username,
-		CreatedAt: now,
-		ExpiresAt: expiresAt,
+		ID:           id,
+		Name:         name,
+		Username:     username,
+		CreatedAt:    now,
+		ExpiresAt:    expiresAt,
+		Scopes:       expanded,
+		Restrictions: restrictions,
 	}
 
 	query := `
-INSERT INTO api_tokens (id, name, token_hash, username, created_at, expires_at)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO api_tokens (id, name, token_hash, username, created_at, expires_at, scopes, restrictions)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 	err = s.db.Transaction(func(tx *sql.Tx) error {
-		_, err := tx.Exec(query, id, name, tokenHash, username, now, expiresAt)
+		_, err := tx.Exec(query, id, name, tokenHash, username, now, expiresAt, string(scopesJSON), restrictionsJSON)
 		return err
 	})
 
@@ -89,16 +147,13 @@ func (s *TokenService) VerifyToken(token string) (*APIToken, error) {
 	hash := sha256.Sum256([]byte(token))
 	tokenHash := hex.EncodeToString(hash[:])
 
-	var apiToken APIToken
-	query := `
-SELECT id, name, username, created_at, expires_at, revoked_at, last_used_at
-FROM api_tokens
-WHERE token_hash = ?
-`
+	var apiToken *APIToken
+	query := "SELECT " + tokenColumns + " FROM api_tokens WHERE token_hash = ?"
 
 	err := s.db.Transaction(func(tx *sql.Tx) error {
-		row := tx.QueryRow(query, tokenHash)
-		return row.Scan(&apiToken.ID, &apiToken.Name, &apiToken.Username, &apiToken.CreatedAt, &apiToken.ExpiresAt, &apiToken.RevokedAt, &apiToken.LastUsedAt)
+		var err error
+		apiToken, err = scanToken(tx.QueryRow(query, tokenHash))
+		return err
 	})
 
 	if err != nil {
@@ -118,7 +173,7 @@ WHERE token_hash = ?
 	// Update last used
 	go s.updateLastUsed(apiToken.ID)
 
-	return &apiToken, nil
+	return apiToken, nil
 }
 
 func (s *TokenService) updateLastUsed(id string) {
@@ -150,18 +205,18 @@ func (s *TokenService) RevokeToken(id, username string) error {
 func (s *TokenService) ListTokens(username string) ([]APIToken, error) {
 	var tokens []APIToken
 	err := s.db.Transaction(func(tx *sql.Tx) error {
-		rows, err := tx.Query("SELECT id, name, username, created_at, expires_at, revoked_at, last_used_at FROM api_tokens WHERE username = ? AND revoked_at IS NULL ORDER BY created_at DESC", username)
+		rows, err := tx.Query("SELECT "+tokenColumns+" FROM api_tokens WHERE username = ? AND revoked_at IS NULL ORDER BY created_at DESC", username)
 		if err != nil {
 			return err
 		}
 		defer rows.Close()
 
 		for rows.Next() {
-			var t APIToken
-			if err := rows.Scan(&t.ID, &t.Name, &t.Username, &t.CreatedAt, &t.ExpiresAt, &t.RevokedAt, &t.LastUsedAt); err != nil {
+			t, err := scanToken(rows)
+			if err != nil {
 				return err
 			}
-			tokens = append(tokens, t)
+			tokens = append(tokens, *t)
 		}
 		return nil
 	})
@@ -170,13 +225,15 @@ func (s *TokenService) ListTokens(username string) ([]APIToken, error) {
 
 // GetToken gets a specific token
 func (s *TokenService) GetToken(id, username string) (*APIToken, error) {
-	var t APIToken
+	var t *APIToken
 	err := s.db.Transaction(func(tx *sql.Tx) error {
-		row := tx.QueryRow("SELECT id, name, username, created_at, expires_at, revoked_at, last_used_at FROM api_tokens WHERE id = ? AND username = ?", id, username)
-		return row.Scan(&t.ID, &t.Name, &t.Username, &t.CreatedAt, &t.ExpiresAt, &t.RevokedAt, &t.LastUsedAt)
+		row := tx.QueryRow("SELECT "+tokenColumns+" FROM api_tokens WHERE id = ? AND username = ?", id, username)
+		var err error
+		t, err = scanToken(row)
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &t, nil
+	return t, nil
 }
@@ -70,6 +70,18 @@ func GetAllMigrations() []database.Migration {
 			Description: "Create API tokens table",
 			SQL:         apiTokenSchema,
 		},
+		// Version 11: Role-based access control
+		{
+			Version:     11,
+			Description: "Create RBAC role and group mapping tables",
+			SQL:         rbacSchema,
+		},
+		// Version 12: API token scopes
+		{
+			Version:     12,
+			Description: "Add scopes and restrictions to API tokens",
+			SQL:         apiTokenScopesSchema,
+		},
 	}
 }
 
@@ -320,3 +332,42 @@ CREATE TABLE api_tokens (
 CREATE INDEX idx_api_tokens_token_hash ON api_tokens(token_hash);
 CREATE INDEX idx_api_tokens_username ON api_tokens(username);
 `
+
+// RBAC schema
+const rbacSchema = `
+CREATE TABLE rbac_roles (
+    name TEXT PRIMARY KEY,
+    description TEXT,
+    permissions TEXT NOT NULL, -- JSON array of "resource:verb" strings
+    built_in INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE TABLE rbac_group_roles (
+    group_name TEXT NOT NULL,
+    role_name TEXT NOT NULL REFERENCES rbac_roles(name) ON DELETE CASCADE,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (group_name, role_name)
+);
+CREATE INDEX idx_rbac_group_roles_role ON rbac_group_roles(role_name);
+
+-- Built-in roles are kept in sync by the auth package on startup; seed them
+-- here so the default group mappings below satisfy the foreign key.
+INSERT INTO rbac_roles (name, description, permissions, built_in, created_at, updated_at) VALUES
+    ('admin', 'Full access to all resources', '["*:*"]', 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('operator', 'Read access to all resources and power actions on virtual machines', '["*:read","virtualization.computes:action","!kubernetes.secrets:*","!auth.roles:*"]', 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    ('viewer', 'Read-only access to all resources except secrets', '["*:read","!kubernetes.secrets:*","!auth.roles:*"]', 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+
+-- Default mappings preserve the previous behaviour of granting full access to sudoers
+INSERT INTO rbac_group_roles (group_name, role_name, created_at) VALUES
+    ('sudo', 'admin', CURRENT_TIMESTAMP),
+    ('wheel', 'admin', CURRENT_TIMESTAMP),
+    ('libvirt', 'operator', CURRENT_TIMESTAMP);
+`
+
+// API token scopes extension
+const apiTokenScopesSchema = `
+	ALTER TABLE api_tokens ADD COLUMN scopes TEXT; -- JSON array of "resource:verb" strings
+	ALTER TABLE api_tokens ADD COLUMN restrictions TEXT; -- JSON object with namespaces and vm_patterns
+`
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/awanio/vapor/internal/ansible"
+	"github.com/awanio/vapor/internal/auth"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/robfig/cron/v3"
@@ -43,37 +44,43 @@ func AnsibleRoutes(r *gin.RouterGroup, ansibleExec *ansible.Executor) {
 
 	ansibleGroup := r.Group("/ansible")
 	{
+		playbooks := ansibleGroup.Group("", auth.Authorize("ansible.playbooks"))
+		adhoc := ansibleGroup.Group("", auth.Authorize("ansible.adhoc"))
+		executions := ansibleGroup.Group("", auth.Authorize("ansible.executions"))
+		inventory := ansibleGroup.Group("", auth.Authorize("ansible.inventory"))
+		schedules := ansibleGroup.Group("", auth.Authorize("ansible.schedules"))
+
 		// Playbook operations
-		ansibleGroup.GET("/playbooks", listPlaybooks(playbookMgr))
-		ansibleGroup.POST("/playbooks/run", runPlaybook(ansibleExec))
-		ansibleGroup.POST("/playbooks/validate", validatePlaybook(ansibleExec))
-		ansibleGroup.POST("/playbooks/upload", uploadPlaybook(playbookMgr))
-		ansibleGroup.GET("/playbooks/templates", getTemplates(playbookMgr))
-		ansibleGroup.POST("/playbooks/from-template", createFromTemplate(playbookMgr))
-		ansibleGroup.POST("/playbooks/sync-git", syncFromGit(playbookMgr))
-		ansibleGroup.POST("/playbooks/from-galaxy", installFromGalaxy(playbookMgr))
-		ansibleGroup.POST("/playbooks/from-url", downloadFromURL(playbookMgr))
-		ansibleGroup.GET("/playbooks/:name", getPlaybookDetails(playbookMgr))
-		ansibleGroup.DELETE("/playbooks/:name", deletePlaybook(playbookMgr))
+		playbooks.GET("/playbooks", listPlaybooks(playbookMgr))
+		playbooks.POST("/playbooks/run", runPlaybook(ansibleExec))
+		playbooks.POST("/playbooks/validate", validatePlaybook(ansibleExec))
+		playbooks.POST("/playbooks/upload", uploadPlaybook(playbookMgr))
+		playbooks.GET("/playbooks/templates", getTemplates(playbookMgr))
+		playbooks.POST("/playbooks/from-template", createFromTemplate(playbookMgr))
+		playbooks.POST("/playbooks/sync-git", syncFromGit(playbookMgr))
+		playbooks.POST("/playbooks/from-galaxy", installFromGalaxy(playbookMgr))
+		playbooks.POST("/playbooks/from-url", downloadFromURL(playbookMgr))
+		playbooks.GET("/playbooks/:name", getPlaybookDetails(playbookMgr))
+		playbooks.DELETE("/playbooks/:name", deletePlaybook(playbookMgr))
 
 		// Ad-hoc commands
-		ansibleGroup.POST("/adhoc", runAdHoc(ansibleExec))
+		adhoc.POST("/adhoc", runAdHoc(ansibleExec))
 
 		// Execution management
-		ansibleGroup.GET("/executions", listExecutions(ansibleExec))
-		ansibleGroup.GET("/executions/:id", getExecution(ansibleExec))
-		ansibleGroup.GET("/executions/:id/stream", streamExecution(ansibleExec))
-		ansibleGroup.DELETE("/executions/:id", cancelExecution(ansibleExec))
+		executions.GET("/executions", listExecutions(ansibleExec))
+		executions.GET("/executions/:id", getExecution(ansibleExec))
+		executions.GET("/executions/:id/stream", streamExecution(ansibleExec))
+		executions.DELETE("/executions/:id", cancelExecution(ansibleExec))
 
 		// Inventory management
-		ansibleGroup.GET("/inventory/dynamic", generateDynamicInventory(ansibleExec))
-		ansibleGroup.POST("/inventory", saveInventory(ansibleExec))
-		ansibleGroup.GET("/inventory/:name", getInventory(ansibleExec))
+		inventory.GET("/inventory/dynamic", generateDynamicInventory(ansibleExec))
+		inventory.POST("/inventory", saveInventory(ansibleExec))
+		inventory.GET("/inventory/:name", getInventory(ansibleExec))
 
 		// Scheduled tasks
-		ansibleGroup.POST("/schedules", createSchedule(ansibleExec))
-		ansibleGroup.GET("/schedules", listSchedules(ansibleExec))
-		ansibleGroup.DELETE("/schedules/:id", deleteSchedule(ansibleExec))
+		schedules.POST("/schedules", createSchedule(ansibleExec))
+		schedules.GET("/schedules", listSchedules(ansibleExec))
+		schedules.DELETE("/schedules/:id", deleteSchedule(ansibleExec))
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/awanio/vapor/internal/auth"
 	"github.com/awanio/vapor/internal/container"
 	"github.com/gin-gonic/gin"
 )
@@ -39,7 +40,7 @@ func ContainerRoutes(r *gin.RouterGroup) {
 
 	// Also register under /containers/images/upload for compatibility
 
-	containersGroup := r.Group("/containers")
+	containersGroup := r.Group("/containers", auth.Authorize("containers"))
 	{
 		// Register container routes for containerd and CRI-O
 		containersGroup.GET("", containerService.ListContainers)
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/awanio/vapor/internal/auth"
 	"github.com/awanio/vapor/internal/docker"
 	"github.com/gin-gonic/gin"
 )
@@ -21,33 +22,39 @@ func DockerRoutes(r *gin.RouterGroup) {
 	// Note: The client needs to be closed when the server shuts down
 	// This should be handled by the main function
 
+	containers := r.Group("", auth.Authorize("docker.containers"))
+	images := r.Group("", auth.Authorize("docker.images"))
+	networks := r.Group("", auth.Authorize("docker.networks"))
+	volumes := r.Group("", auth.Authorize("docker.volumes"))
+	containerActions := r.Group("", auth.RequirePermission("docker.containers", auth.VerbAction))
+
 	// Register Docker routes
-	r.GET("/docker/ps", dockerService.ListContainersGin)
-	r.GET("/docker/images", dockerService.ListImagesGin)
-	r.GET("/docker/networks", dockerService.ListNetworksGin)
-	r.GET("/docker/volumes", dockerService.ListVolumesGin)
+	containers.GET("/docker/ps", dockerService.ListContainersGin)
+	images.GET("/docker/images", dockerService.ListImagesGin)
+	networks.GET("/docker/networks", dockerService.ListNetworksGin)
+	volumes.GET("/docker/volumes", dockerService.ListVolumesGin)
 
 	// Container creation and image pulling
-	r.POST("/docker/containers", dockerService.CreateContainerGin)
-	r.POST("/docker/images/pull", dockerService.PullImageGin)
-	r.POST("/docker/images/import", dockerService.ImportImageGin)
+	containers.POST("/docker/containers", dockerService.CreateContainerGin)
+	images.POST("/docker/images/pull", dockerService.PullImageGin)
+	images.POST("/docker/images/import", dockerService.ImportImageGin)
 
 	// Container detail and actions
-	r.GET("/docker/containers/:id", dockerService.GetContainerDetailGin)
-	r.DELETE("/docker/containers/:id", dockerService.RemoveContainerGin)
-	r.POST("/docker/containers/:id/start", dockerService.StartContainerGin)
-	r.POST("/docker/containers/:id/stop", dockerService.StopContainerGin)
-	r.POST("/docker/containers/:id/kill", dockerService.KillContainerGin)
-	r.GET("/docker/containers/:id/logs", dockerService.GetContainerLogsGin)
+	containers.GET("/docker/containers/:id", dockerService.GetContainerDetailGin)
+	containers.DELETE("/docker/containers/:id", dockerService.RemoveContainerGin)
+	containerActions.POST("/docker/containers/:id/start", dockerService.StartContainerGin)
+	containerActions.POST("/docker/containers/:id/stop", dockerService.StopContainerGin)
+	containerActions.POST("/docker/containers/:id/kill", dockerService.KillContainerGin)
+	containers.GET("/docker/containers/:id/logs", dockerService.GetContainerLogsGin)
 
 	// Resource deletion
-	r.DELETE("/docker/images/:id", dockerService.RemoveImageGin)
-	r.DELETE("/docker/volumes/:id", dockerService.RemoveVolumeGin)
-	r.DELETE("/docker/networks/:id", dockerService.RemoveNetworkGin)
+	images.DELETE("/docker/images/:id", dockerService.RemoveImageGin)
+	volumes.DELETE("/docker/volumes/:id", dockerService.RemoveVolumeGin)
+	networks.DELETE("/docker/networks/:id", dockerService.RemoveNetworkGin)
 
 	// Volume and Network creation
-	r.POST("/docker/volumes", dockerService.CreateVolumeGin)
-	r.POST("/docker/networks", dockerService.CreateNetworkGin)
+	volumes.POST("/docker/volumes", dockerService.CreateVolumeGin)
+	networks.POST("/docker/networks", dockerService.CreateNetworkGin)
 
 	// Setup resumable upload handler for Docker images using TUS protocol
 	// Create a runtime service wrapper that uses the Docker client
@@ -59,15 +66,15 @@ func DockerRoutes(r *gin.RouterGroup) {
 		resumableHandler := docker.NewResumableUploadHandler(dockerServiceWithRuntime, uploadDir)
 
 		// TUS protocol endpoints for Docker image uploads with OPTIONS support
-		r.OPTIONS("/docker/images/upload", resumableHandler.HandleOptions)     // OPTIONS for TUS protocol discovery
-		r.OPTIONS("/docker/images/upload/:id", resumableHandler.HandleOptions) // OPTIONS for TUS protocol discovery
-		r.POST("/docker/images/upload", resumableHandler.CreateUpload)
-		r.GET("/docker/images/upload", resumableHandler.ListUploads)
-		r.HEAD("/docker/images/upload/:id", resumableHandler.GetUploadInfo)
-		r.PATCH("/docker/images/upload/:id", resumableHandler.UploadChunk)
-		r.GET("/docker/images/upload/:id", resumableHandler.GetUploadStatus)
-		r.POST("/docker/images/upload/:id/complete", resumableHandler.CompleteUpload)
-		r.DELETE("/docker/images/upload/:id", resumableHandler.CancelUpload)
+		images.OPTIONS("/docker/images/upload", resumableHandler.HandleOptions)     // OPTIONS for TUS protocol discovery
+		images.OPTIONS("/docker/images/upload/:id", resumableHandler.HandleOptions) // OPTIONS for TUS protocol discovery
+		images.POST("/docker/images/upload", resumableHandler.CreateUpload)
+		images.GET("/docker/images/upload", resumableHandler.ListUploads)
+		images.HEAD("/docker/images/upload/:id", resumableHandler.GetUploadInfo)
+		images.PATCH("/docker/images/upload/:id", resumableHandler.UploadChunk)
+		images.GET("/docker/images/upload/:id", resumableHandler.GetUploadStatus)
+		images.POST("/docker/images/upload/:id/complete", resumableHandler.CompleteUpload)
+		images.DELETE("/docker/images/upload/:id", resumableHandler.CancelUpload)
 	}
 }
 
@@ -75,33 +82,39 @@ func DockerRoutes(r *gin.RouterGroup) {
 func DockerRoutesWithClient(r *gin.RouterGroup, dockerClient docker.Client) {
 	dockerService := docker.NewService(dockerClient)
 
+	containers := r.Group("", auth.Authorize("docker.containers"))
+	images := r.Group("", auth.Authorize("docker.images"))
+	networks := r.Group("", auth.Authorize("docker.networks"))
+	volumes := r.Group("", auth.Authorize("docker.volumes"))
+	containerActions := r.Group("", auth.RequirePermission("docker.containers", auth.VerbAction))
+
 	// Register Docker routes
-	r.GET("/docker/ps", dockerService.ListContainersGin)
-	r.GET("/docker/images", dockerService.ListImagesGin)
-	r.GET("/docker/networks", dockerService.ListNetworksGin)
-	r.GET("/docker/volumes", dockerService.ListVolumesGin)
+	containers.GET("/docker/ps", dockerService.ListContainersGin)
+	images.GET("/docker/images", dockerService.ListImagesGin)
+	networks.GET("/docker/networks", dockerService.ListNetworksGin)
+	volumes.GET("/docker/volumes", dockerService.ListVolumesGin)
 
 	// Container creation and image pulling
-	r.POST("/docker/containers", dockerService.CreateContainerGin)
-	r.POST("/docker/images/pull", dockerService.PullImageGin)
-	r.POST("/docker/images/import", dockerService.ImportImageGin)
+	containers.POST("/docker/containers", dockerService.CreateContainerGin)
+	images.POST("/docker/images/pull", dockerService.PullImageGin)
+	images.POST("/docker/images/import", dockerService.ImportImageGin)
 
 	// Container detail and actions
-	r.GET("/docker/containers/:id", dockerService.GetContainerDetailGin)
-	r.DELETE("/docker/containers/:id", dockerService.RemoveContainerGin)
-	r.POST("/docker/containers/:id/start", dockerService.StartContainerGin)
-	r.POST("/docker/containers/:id/stop", dockerService.StopContainerGin)
-	r.POST("/docker/containers/:id/kill", dockerService.KillContainerGin)
-	r.GET("/docker/containers/:id/logs", dockerService.GetContainerLogsGin)
+	containers.GET("/docker/containers/:id", dockerService.GetContainerDetailGin)
+	containers.DELETE("/docker/containers/:id", dockerService.RemoveContainerGin)
+	containerActions.POST("/docker/containers/:id/start", dockerService.StartContainerGin)
+	containerActions.POST("/docker/containers/:id/stop", dockerService.StopContainerGin)
+	containerActions.POST("/docker/containers/:id/kill", dockerService.KillContainerGin)
+	containers.GET("/docker/containers/:id/logs", dockerService.GetContainerLogsGin)
 
 	// Resource deletion
-	r.DELETE("/docker/images/:id", dockerService.RemoveImageGin)
-	r.DELETE("/docker/volumes/:id", dockerService.RemoveVolumeGin)
-	r.DELETE("/docker/networks/:id", dockerService.RemoveNetworkGin)
+	images.DELETE("/docker/images/:id", dockerService.RemoveImageGin)
+	volumes.DELETE("/docker/volumes/:id", dockerService.RemoveVolumeGin)
+	networks.DELETE("/docker/networks/:id", dockerService.RemoveNetworkGin)
 
 	// Volume and Network creation
-	r.POST("/docker/volumes", dockerService.CreateVolumeGin)
-	r.POST("/docker/networks", dockerService.CreateNetworkGin)
+	volumes.POST("/docker/volumes", dockerService.CreateVolumeGin)
+	networks.POST("/docker/networks", dockerService.CreateNetworkGin)
 
 	// Setup resumable upload handler for Docker images using TUS protocol
 	// Create a runtime service wrapper that uses the Docker client
@@ -113,14 +126,14 @@ func DockerRoutesWithClient(r *gin.RouterGroup, dockerClient docker.Client) {
 		resumableHandler := docker.NewResumableUploadHandler(dockerServiceWithRuntime, uploadDir)
 
 		// TUS protocol endpoints for Docker image uploads with OPTIONS support
-		r.OPTIONS("/docker/images/upload", resumableHandler.HandleOptions)     // OPTIONS for TUS protocol discovery
-		r.OPTIONS("/docker/images/upload/:id", resumableHandler.HandleOptions) // OPTIONS for TUS protocol discovery
-		r.POST("/docker/images/upload", resumableHandler.CreateUpload)
-		r.GET("/docker/images/upload", resumableHandler.ListUploads)
-		r.HEAD("/docker/images/upload/:id", resumableHandler.GetUploadInfo)
-		r.PATCH("/docker/images/upload/:id", resumableHandler.UploadChunk)
-		r.GET("/docker/images/upload/:id", resumableHandler.GetUploadStatus)
-		r.POST("/docker/images/upload/:id/complete", resumableHandler.CompleteUpload)
-		r.DELETE("/docker/images/upload/:id", resumableHandler.CancelUpload)
+		images.OPTIONS("/docker/images/upload", resumableHandler.HandleOptions)     // OPTIONS for TUS protocol discovery
+		images.OPTIONS("/docker/images/upload/:id", resumableHandler.HandleOptions) // OPTIONS for TUS protocol discovery
+		images.POST("/docker/images/upload", resumableHandler.CreateUpload)
+		images.GET("/docker/images/upload", resumableHandler.ListUploads)
+		images.HEAD("/docker/images/upload/:id", resumableHandler.GetUploadInfo)
+		images.PATCH("/docker/images/upload/:id", resumableHandler.UploadChunk)
+		images.GET("/docker/images/upload/:id", resumableHandler.GetUploadStatus)
+		images.POST("/docker/images/upload/:id/complete", resumableHandler.CompleteUpload)
+		images.DELETE("/docker/images/upload/:id", resumableHandler.CancelUpload)
 	}
 }
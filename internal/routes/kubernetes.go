@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/awanio/vapor/internal/auth"
 	"github.com/awanio/vapor/internal/helm"
 	"github.com/awanio/vapor/internal/kubernetes"
 	"github.com/awanio/vapor/internal/websocket"
@@ -33,204 +34,235 @@ func KubernetesRoutes(r *gin.RouterGroup, eventsHub *websocket.Hub) {
 	// Register Kubernetes routes
 	k8sHandler := kubernetes.NewHandler(kubernetesService)
 
+	// Every Kubernetes resource is guarded by its own permission
+	crds := r.Group("", auth.Authorize("kubernetes.customresourcedefinitions"))
+	pods := r.Group("", auth.Authorize("kubernetes.pods"))
+	deployments := r.Group("", auth.Authorize("kubernetes.deployments"))
+	services := r.Group("", auth.Authorize("kubernetes.services"))
+	ingresses := r.Group("", auth.Authorize("kubernetes.ingresses"))
+	pvcs := r.Group("", auth.Authorize("kubernetes.persistentvolumeclaims"))
+	secrets := r.Group("", auth.Authorize("kubernetes.secrets"))
+	configMaps := r.Group("", auth.Authorize("kubernetes.configmaps"))
+	daemonSets := r.Group("", auth.Authorize("kubernetes.daemonsets"))
+	statefulSets := r.Group("", auth.Authorize("kubernetes.statefulsets"))
+	jobs := r.Group("", auth.Authorize("kubernetes.jobs"))
+	cronJobs := r.Group("", auth.Authorize("kubernetes.cronjobs"))
+	networkPolicies := r.Group("", auth.Authorize("kubernetes.networkpolicies"))
+	replicaSets := r.Group("", auth.Authorize("kubernetes.replicasets"))
+	serviceAccounts := r.Group("", auth.Authorize("kubernetes.serviceaccounts"))
+	roles := r.Group("", auth.Authorize("kubernetes.roles"))
+	roleBindings := r.Group("", auth.Authorize("kubernetes.rolebindings"))
+	hpas := r.Group("", auth.Authorize("kubernetes.horizontalpodautoscalers"))
+	pvs := r.Group("", auth.Authorize("kubernetes.persistentvolumes"))
+	namespaces := r.Group("", auth.Authorize("kubernetes.namespaces"))
+	nodes := r.Group("", auth.Authorize("kubernetes.nodes"))
+	cluster := r.Group("", auth.Authorize("kubernetes.cluster"))
+	ingressClasses := r.Group("", auth.Authorize("kubernetes.ingressclasses"))
+
+	// Restarts and node maintenance are actions rather than writes
+	deploymentActions := r.Group("", auth.RequirePermission("kubernetes.deployments", auth.VerbAction))
+	nodeActions := r.Group("", auth.RequirePermission("kubernetes.nodes", auth.VerbAction))
+	daemonSetActions := r.Group("", auth.RequirePermission("kubernetes.daemonsets", auth.VerbAction))
+	statefulSetActions := r.Group("", auth.RequirePermission("kubernetes.statefulsets", auth.VerbAction))
+
 	// CRD routes
-	r.GET("/kubernetes/customresourcedefinitions", k8sHandler.ListCRDsGin)
-	r.GET("/kubernetes/customresourcedefinitions/:name", k8sHandler.GetCRDDetailGin)
+	crds.GET("/kubernetes/customresourcedefinitions", k8sHandler.ListCRDsGin)
+	crds.GET("/kubernetes/customresourcedefinitions/:name", k8sHandler.GetCRDDetailGin)
 	// CRD definition manipulation routes
-	r.POST("/kubernetes/customresourcedefinitions", k8sHandler.ApplyCRDGin)
-	r.PUT("/kubernetes/customresourcedefinitions/:name", k8sHandler.UpdateCRDGin)
-	r.PATCH("/kubernetes/customresourcedefinitions/:name", k8sHandler.UpdateCRDGin)
-	r.DELETE("/kubernetes/customresourcedefinitions/:name", k8sHandler.DeleteCRDGin)
-	r.GET("/kubernetes/customresourcedefinitions/:name/instances", k8sHandler.ListCRDObjectsGin)
-	r.GET("/kubernetes/customresourcedefinitions/:name/instances/:namespace/:object-name", k8sHandler.GetCRDObjectDetailGin)
+	crds.POST("/kubernetes/customresourcedefinitions", k8sHandler.ApplyCRDGin)
+	crds.PUT("/kubernetes/customresourcedefinitions/:name", k8sHandler.UpdateCRDGin)
+	crds.PATCH("/kubernetes/customresourcedefinitions/:name", k8sHandler.UpdateCRDGin)
+	crds.DELETE("/kubernetes/customresourcedefinitions/:name", k8sHandler.DeleteCRDGin)
+	crds.GET("/kubernetes/customresourcedefinitions/:name/instances", k8sHandler.ListCRDObjectsGin)
+	crds.GET("/kubernetes/customresourcedefinitions/:name/instances/:namespace/:object-name", k8sHandler.GetCRDObjectDetailGin)
 	// CRD instance manipulation routes
-	r.POST("/kubernetes/customresourcedefinitions/:name/instances", k8sHandler.CreateCRDObjectGin)
-	r.PUT("/kubernetes/customresourcedefinitions/:name/instances/:namespace/:object-name", k8sHandler.UpdateCRDObjectGin)
-	r.PATCH("/kubernetes/customresourcedefinitions/:name/instances/:namespace/:object-name", k8sHandler.UpdateCRDObjectGin)
-	r.DELETE("/kubernetes/customresourcedefinitions/:name/instances/:namespace/:object-name", k8sHandler.DeleteCRDObjectGin)
+	crds.POST("/kubernetes/customresourcedefinitions/:name/instances", k8sHandler.CreateCRDObjectGin)
+	crds.PUT("/kubernetes/customresourcedefinitions/:name/instances/:namespace/:object-name", k8sHandler.UpdateCRDObjectGin)
+	crds.PATCH("/kubernetes/customresourcedefinitions/:name/instances/:namespace/:object-name", k8sHandler.UpdateCRDObjectGin)
+	crds.DELETE("/kubernetes/customresourcedefinitions/:name/instances/:namespace/:object-name", k8sHandler.DeleteCRDObjectGin)
 
 	// ====== NAMESPACE-SCOPED LIST ROUTES ======
 	// These routes allow listing resources filtered by a specific namespace
 
 	// Pod namespace-scoped routes
-	r.GET("/kubernetes/pods/:namespace", k8sHandler.ListPodsByNamespaceGin)
+	pods.GET("/kubernetes/pods/:namespace", k8sHandler.ListPodsByNamespaceGin)
 
 	// Deployment namespace-scoped routes
-	r.GET("/kubernetes/deployments/:namespace", k8sHandler.ListDeploymentsByNamespaceGin)
+	deployments.GET("/kubernetes/deployments/:namespace", k8sHandler.ListDeploymentsByNamespaceGin)
 
 	// Service namespace-scoped routes
-	r.GET("/kubernetes/services/:namespace", k8sHandler.ListServicesByNamespaceGin)
+	services.GET("/kubernetes/services/:namespace", k8sHandler.ListServicesByNamespaceGin)
 
 	// Ingress namespace-scoped routes
-	r.GET("/kubernetes/ingresses/:namespace", k8sHandler.ListIngressesByNamespaceGin)
+	ingresses.GET("/kubernetes/ingresses/:namespace", k8sHandler.ListIngressesByNamespaceGin)
 
 	// PVC namespace-scoped routes
-	r.GET("/kubernetes/persistentvolumeclaims/:namespace", k8sHandler.ListPVCsByNamespaceGin)
+	pvcs.GET("/kubernetes/persistentvolumeclaims/:namespace", k8sHandler.ListPVCsByNamespaceGin)
 
 	// Secret namespace-scoped routes
-	r.GET("/kubernetes/secrets/:namespace", k8sHandler.ListSecretsByNamespaceGin)
+	secrets.GET("/kubernetes/secrets/:namespace", k8sHandler.ListSecretsByNamespaceGin)
 
 	// ConfigMap namespace-scoped routes
-	r.GET("/kubernetes/configmaps/:namespace", k8sHandler.ListConfigMapsByNamespaceGin)
+	configMaps.GET("/kubernetes/configmaps/:namespace", k8sHandler.ListConfigMapsByNamespaceGin)
 
 	// DaemonSet namespace-scoped routes
-	r.GET("/kubernetes/daemonsets/:namespace", k8sHandler.ListDaemonSetsByNamespaceGin)
+	daemonSets.GET("/kubernetes/daemonsets/:namespace", k8sHandler.ListDaemonSetsByNamespaceGin)
 
 	// StatefulSet namespace-scoped routes
-	r.GET("/kubernetes/statefulsets/:namespace", k8sHandler.ListStatefulSetsByNamespaceGin)
+	statefulSets.GET("/kubernetes/statefulsets/:namespace", k8sHandler.ListStatefulSetsByNamespaceGin)
 
 	// Job namespace-scoped routes
-	r.GET("/kubernetes/jobs/:namespace", k8sHandler.ListJobsByNamespaceGin)
+	jobs.GET("/kubernetes/jobs/:namespace", k8sHandler.ListJobsByNamespaceGin)
 
 	// CronJob namespace-scoped routes
-	r.GET("/kubernetes/cronjobs/:namespace", k8sHandler.ListCronJobsByNamespaceGin)
+	cronJobs.GET("/kubernetes/cronjobs/:namespace", k8sHandler.ListCronJobsByNamespaceGin)
 
 	// NetworkPolicy namespace-scoped routes
-	r.GET("/kubernetes/networkpolicies/:namespace", k8sHandler.ListNetworkPoliciesByNamespaceGin)
+	networkPolicies.GET("/kubernetes/networkpolicies/:namespace", k8sHandler.ListNetworkPoliciesByNamespaceGin)
 
 	// Additional namespaced resources
-	r.GET("/kubernetes/replicasets/:namespace", k8sHandler.ListReplicaSetsByNamespaceGin)
-	r.GET("/kubernetes/serviceaccounts/:namespace", k8sHandler.ListServiceAccountsByNamespaceGin)
-	r.GET("/kubernetes/roles/:namespace", k8sHandler.ListRolesByNamespaceGin)
-	r.GET("/kubernetes/rolebindings/:namespace", k8sHandler.ListRoleBindingsByNamespaceGin)
-	r.GET("/kubernetes/horizontalpodautoscalers/:namespace", k8sHandler.ListHorizontalPodAutoscalersByNamespaceGin)
+	replicaSets.GET("/kubernetes/replicasets/:namespace", k8sHandler.ListReplicaSetsByNamespaceGin)
+	serviceAccounts.GET("/kubernetes/serviceaccounts/:namespace", k8sHandler.ListServiceAccountsByNamespaceGin)
+	roles.GET("/kubernetes/roles/:namespace", k8sHandler.ListRolesByNamespaceGin)
+	roleBindings.GET("/kubernetes/rolebindings/:namespace", k8sHandler.ListRoleBindingsByNamespaceGin)
+	hpas.GET("/kubernetes/horizontalpodautoscalers/:namespace", k8sHandler.ListHorizontalPodAutoscalersByNamespaceGin)
 
 	// ====== END NAMESPACE-SCOPED LIST ROUTES ======
 
 	// Pod routes
-	r.GET("/kubernetes/pods", k8sHandler.ListPodsGin)
-	r.GET("/kubernetes/pods/:namespace/:name", k8sHandler.GetPodDetailGin)
-	r.GET("/kubernetes/pods/:namespace/:name/logs", k8sHandler.GetPodLogsGin)
-	r.DELETE("/kubernetes/pods/:namespace/:name", k8sHandler.DeletePodGin)
-	r.POST("/kubernetes/pods", k8sHandler.ApplyPodGin)
-	r.PUT("/kubernetes/pods/:namespace/:name", k8sHandler.UpdatePodGin)
+	pods.GET("/kubernetes/pods", k8sHandler.ListPodsGin)
+	pods.GET("/kubernetes/pods/:namespace/:name", k8sHandler.GetPodDetailGin)
+	pods.GET("/kubernetes/pods/:namespace/:name/logs", k8sHandler.GetPodLogsGin)
+	pods.DELETE("/kubernetes/pods/:namespace/:name", k8sHandler.DeletePodGin)
+	pods.POST("/kubernetes/pods", k8sHandler.ApplyPodGin)
+	pods.PUT("/kubernetes/pods/:namespace/:name", k8sHandler.UpdatePodGin)
 
 	// Pod rollout routes (only set-image is supported for Pods)
-	r.PATCH("/kubernetes/pods/:namespace/:name/rollout/images", k8sHandler.RolloutSetImagePodGin)
+	pods.PATCH("/kubernetes/pods/:namespace/:name/rollout/images", k8sHandler.RolloutSetImagePodGin)
 
 	// Deployment routes
-	r.GET("/kubernetes/deployments", k8sHandler.ListDeploymentsGin)
-	r.GET("/kubernetes/deployments/:namespace/:name", k8sHandler.GetDeploymentDetailGin)
-	r.POST("/kubernetes/deployments", k8sHandler.ApplyDeploymentGin)
-	r.PUT("/kubernetes/deployments/:namespace/:name", k8sHandler.UpdateDeploymentGin)
-	r.DELETE("/kubernetes/deployments/:namespace/:name", k8sHandler.DeleteDeploymentGin)
+	deployments.GET("/kubernetes/deployments", k8sHandler.ListDeploymentsGin)
+	deployments.GET("/kubernetes/deployments/:namespace/:name", k8sHandler.GetDeploymentDetailGin)
+	deployments.POST("/kubernetes/deployments", k8sHandler.ApplyDeploymentGin)
+	deployments.PUT("/kubernetes/deployments/:namespace/:name", k8sHandler.UpdateDeploymentGin)
+	deployments.DELETE("/kubernetes/deployments/:namespace/:name", k8sHandler.DeleteDeploymentGin)
 
 	// Deployment rollout routes
-	r.PATCH("/kubernetes/deployments/:namespace/:name/rollout/restart", k8sHandler.RolloutRestartDeploymentGin)
-	r.PATCH("/kubernetes/deployments/:namespace/:name/rollout/undo", k8sHandler.RolloutUndoDeploymentGin)
-	r.PATCH("/kubernetes/deployments/:namespace/:name/rollout/images", k8sHandler.RolloutSetImageDeploymentGin)
+	deploymentActions.PATCH("/kubernetes/deployments/:namespace/:name/rollout/restart", k8sHandler.RolloutRestartDeploymentGin)
+	deployments.PATCH("/kubernetes/deployments/:namespace/:name/rollout/undo", k8sHandler.RolloutUndoDeploymentGin)
+	deployments.PATCH("/kubernetes/deployments/:namespace/:name/rollout/images", k8sHandler.RolloutSetImageDeploymentGin)
 
 	// Service routes
-	r.GET("/kubernetes/services", k8sHandler.ListServicesGin)
-	r.GET("/kubernetes/services/:namespace/:name", k8sHandler.GetServiceDetailGin)
-	r.POST("/kubernetes/services", k8sHandler.ApplyServiceGin)
-	r.PUT("/kubernetes/services/:namespace/:name", k8sHandler.UpdateServiceGin)
-	r.DELETE("/kubernetes/services/:namespace/:name", k8sHandler.DeleteServiceGin)
+	services.GET("/kubernetes/services", k8sHandler.ListServicesGin)
+	services.GET("/kubernetes/services/:namespace/:name", k8sHandler.GetServiceDetailGin)
+	services.POST("/kubernetes/services", k8sHandler.ApplyServiceGin)
+	services.PUT("/kubernetes/services/:namespace/:name", k8sHandler.UpdateServiceGin)
+	services.DELETE("/kubernetes/services/:namespace/:name", k8sHandler.DeleteServiceGin)
 
 	// Ingress routes
-	r.GET("/kubernetes/ingresses", k8sHandler.ListIngressesGin)
-	r.GET("/kubernetes/ingresses/:namespace/:name", k8sHandler.GetIngressDetailGin)
-	r.POST("/kubernetes/ingresses", k8sHandler.ApplyIngressGin)
-	r.PUT("/kubernetes/ingresses/:namespace/:name", k8sHandler.UpdateIngressGin)
-	r.DELETE("/kubernetes/ingresses/:namespace/:name", k8sHandler.DeleteIngressGin)
+	ingresses.GET("/kubernetes/ingresses", k8sHandler.ListIngressesGin)
+	ingresses.GET("/kubernetes/ingresses/:namespace/:name", k8sHandler.GetIngressDetailGin)
+	ingresses.POST("/kubernetes/ingresses", k8sHandler.ApplyIngressGin)
+	ingresses.PUT("/kubernetes/ingresses/:namespace/:name", k8sHandler.UpdateIngressGin)
+	ingresses.DELETE("/kubernetes/ingresses/:namespace/:name", k8sHandler.DeleteIngressGin)
 
 	// PVC routes
-	r.GET("/kubernetes/persistentvolumeclaims", k8sHandler.ListPVCsGin)
-	r.GET("/kubernetes/persistentvolumeclaims/:namespace/:name", k8sHandler.GetPVCDetailGin)
-	r.POST("/kubernetes/persistentvolumeclaims", k8sHandler.ApplyPVCGin)
-	r.PUT("/kubernetes/persistentvolumeclaims/:namespace/:name", k8sHandler.UpdatePVCGin)
-	r.DELETE("/kubernetes/persistentvolumeclaims/:namespace/:name", k8sHandler.DeletePVCGin)
+	pvcs.GET("/kubernetes/persistentvolumeclaims", k8sHandler.ListPVCsGin)
+	pvcs.GET("/kubernetes/persistentvolumeclaims/:namespace/:name", k8sHandler.GetPVCDetailGin)
+	pvcs.POST("/kubernetes/persistentvolumeclaims", k8sHandler.ApplyPVCGin)
+	pvcs.PUT("/kubernetes/persistentvolumeclaims/:namespace/:name", k8sHandler.UpdatePVCGin)
+	pvcs.DELETE("/kubernetes/persistentvolumeclaims/:namespace/:name", k8sHandler.DeletePVCGin)
 
 	// PV routes
-	r.GET("/kubernetes/persistentvolumes", k8sHandler.ListPVsGin)
-	r.GET("/kubernetes/persistentvolumes/:name", k8sHandler.GetPVDetailGin)
-	r.POST("/kubernetes/persistentvolumes", k8sHandler.ApplyPVGin)
-	r.PUT("/kubernetes/persistentvolumes/:name", k8sHandler.UpdatePVGin)
-	r.DELETE("/kubernetes/persistentvolumes/:name", k8sHandler.DeletePVGin)
+	pvs.GET("/kubernetes/persistentvolumes", k8sHandler.ListPVsGin)
+	pvs.GET("/kubernetes/persistentvolumes/:name", k8sHandler.GetPVDetailGin)
+	pvs.POST("/kubernetes/persistentvolumes", k8sHandler.ApplyPVGin)
+	pvs.PUT("/kubernetes/persistentvolumes/:name", k8sHandler.UpdatePVGin)
+	pvs.DELETE("/kubernetes/persistentvolumes/:name", k8sHandler.DeletePVGin)
 
 	// Secret routes
-	r.GET("/kubernetes/secrets", k8sHandler.ListSecretsGin)
-	r.GET("/kubernetes/secrets/:namespace/:name", k8sHandler.GetSecretDetailGin)
-	r.POST("/kubernetes/secrets", k8sHandler.ApplySecretGin)
-	r.PUT("/kubernetes/secrets/:namespace/:name", k8sHandler.UpdateSecretGin)
-	r.DELETE("/kubernetes/secrets/:namespace/:name", k8sHandler.DeleteSecretGin)
+	secrets.GET("/kubernetes/secrets", k8sHandler.ListSecretsGin)
+	secrets.GET("/kubernetes/secrets/:namespace/:name", k8sHandler.GetSecretDetailGin)
+	secrets.POST("/kubernetes/secrets", k8sHandler.ApplySecretGin)
+	secrets.PUT("/kubernetes/secrets/:namespace/:name", k8sHandler.UpdateSecretGin)
+	secrets.DELETE("/kubernetes/secrets/:namespace/:name", k8sHandler.DeleteSecretGin)
 
 	// ConfigMap routes
-	r.GET("/kubernetes/configmaps", k8sHandler.ListConfigMapsGin)
-	r.GET("/kubernetes/configmaps/:namespace/:name", k8sHandler.GetConfigMapDetailGin)
-	r.POST("/kubernetes/configmaps", k8sHandler.ApplyConfigMapGin)
-	r.PUT("/kubernetes/configmaps/:namespace/:name", k8sHandler.UpdateConfigMapGin)
-	r.DELETE("/kubernetes/configmaps/:namespace/:name", k8sHandler.DeleteConfigMapGin)
+	configMaps.GET("/kubernetes/configmaps", k8sHandler.ListConfigMapsGin)
+	configMaps.GET("/kubernetes/configmaps/:namespace/:name", k8sHandler.GetConfigMapDetailGin)
+	configMaps.POST("/kubernetes/configmaps", k8sHandler.ApplyConfigMapGin)
+	configMaps.PUT("/kubernetes/configmaps/:namespace/:name", k8sHandler.UpdateConfigMapGin)
+	configMaps.DELETE("/kubernetes/configmaps/:namespace/:name", k8sHandler.DeleteConfigMapGin)
 
 	// Namespace routes
-	r.GET("/kubernetes/namespaces", k8sHandler.ListNamespacesGin)
-	r.GET("/kubernetes/namespaces/:name", k8sHandler.GetNamespaceDetailGin)
-	r.POST("/kubernetes/namespaces", k8sHandler.ApplyNamespaceGin)
-	r.PUT("/kubernetes/namespaces/:name", k8sHandler.UpdateNamespaceGin)
-	r.DELETE("/kubernetes/namespaces/:name", k8sHandler.DeleteNamespaceGin)
+	namespaces.GET("/kubernetes/namespaces", k8sHandler.ListNamespacesGin)
+	namespaces.GET("/kubernetes/namespaces/:name", k8sHandler.GetNamespaceDetailGin)
+	namespaces.POST("/kubernetes/namespaces", k8sHandler.ApplyNamespaceGin)
+	namespaces.PUT("/kubernetes/namespaces/:name", k8sHandler.UpdateNamespaceGin)
+	namespaces.DELETE("/kubernetes/namespaces/:name", k8sHandler.DeleteNamespaceGin)
 
 	// Node routes
-	r.GET("/kubernetes/nodes", k8sHandler.ListNodesGin)
-	r.GET("/kubernetes/nodes/:name", k8sHandler.GetNodeDetailGin)
+	nodes.GET("/kubernetes/nodes", k8sHandler.ListNodesGin)
+	nodes.GET("/kubernetes/nodes/:name", k8sHandler.GetNodeDetailGin)
 	// Node operation routes
-	r.PATCH("/kubernetes/nodes/:name/cordon", k8sHandler.CordonNodeGin)
-	r.PATCH("/kubernetes/nodes/:name/uncordon", k8sHandler.UncordonNodeGin)
-	r.POST("/kubernetes/nodes/:name/drain", k8sHandler.DrainNodeGin)
+	nodeActions.PATCH("/kubernetes/nodes/:name/cordon", k8sHandler.CordonNodeGin)
+	nodeActions.PATCH("/kubernetes/nodes/:name/uncordon", k8sHandler.UncordonNodeGin)
+	nodeActions.POST("/kubernetes/nodes/:name/drain", k8sHandler.DrainNodeGin)
 
 	// DaemonSet routes
-	r.GET("/kubernetes/daemonsets", k8sHandler.ListDaemonSetsGin)
-	r.GET("/kubernetes/daemonsets/:namespace/:name", k8sHandler.GetDaemonSetDetailGin)
-	r.POST("/kubernetes/daemonsets", k8sHandler.ApplyDaemonSetGin)
-	r.PUT("/kubernetes/daemonsets/:namespace/:name", k8sHandler.UpdateDaemonSetGin)
-	r.DELETE("/kubernetes/daemonsets/:namespace/:name", k8sHandler.DeleteDaemonSetGin)
+	daemonSets.GET("/kubernetes/daemonsets", k8sHandler.ListDaemonSetsGin)
+	daemonSets.GET("/kubernetes/daemonsets/:namespace/:name", k8sHandler.GetDaemonSetDetailGin)
+	daemonSets.POST("/kubernetes/daemonsets", k8sHandler.ApplyDaemonSetGin)
+	daemonSets.PUT("/kubernetes/daemonsets/:namespace/:name", k8sHandler.UpdateDaemonSetGin)
+	daemonSets.DELETE("/kubernetes/daemonsets/:namespace/:name", k8sHandler.DeleteDaemonSetGin)
 
 	// DaemonSet rollout routes
-	r.PATCH("/kubernetes/daemonsets/:namespace/:name/rollout/restart", k8sHandler.RolloutRestartDaemonSetGin)
-	r.PATCH("/kubernetes/daemonsets/:namespace/:name/rollout/undo", k8sHandler.RolloutUndoDaemonSetGin)
-	r.PATCH("/kubernetes/daemonsets/:namespace/:name/rollout/images", k8sHandler.RolloutSetImageDaemonSetGin)
+	daemonSetActions.PATCH("/kubernetes/daemonsets/:namespace/:name/rollout/restart", k8sHandler.RolloutRestartDaemonSetGin)
+	daemonSets.PATCH("/kubernetes/daemonsets/:namespace/:name/rollout/undo", k8sHandler.RolloutUndoDaemonSetGin)
+	daemonSets.PATCH("/kubernetes/daemonsets/:namespace/:name/rollout/images", k8sHandler.RolloutSetImageDaemonSetGin)
 
 	// StatefulSet routes
-	r.GET("/kubernetes/statefulsets", k8sHandler.ListStatefulSetsGin)
-	r.GET("/kubernetes/statefulsets/:namespace/:name", k8sHandler.GetStatefulSetDetailGin)
-	r.POST("/kubernetes/statefulsets", k8sHandler.ApplyStatefulSetGin)
-	r.PUT("/kubernetes/statefulsets/:namespace/:name", k8sHandler.UpdateStatefulSetGin)
-	r.DELETE("/kubernetes/statefulsets/:namespace/:name", k8sHandler.DeleteStatefulSetGin)
+	statefulSets.GET("/kubernetes/statefulsets", k8sHandler.ListStatefulSetsGin)
+	statefulSets.GET("/kubernetes/statefulsets/:namespace/:name", k8sHandler.GetStatefulSetDetailGin)
+	statefulSets.POST("/kubernetes/statefulsets", k8sHandler.ApplyStatefulSetGin)
+	statefulSets.PUT("/kubernetes/statefulsets/:namespace/:name", k8sHandler.UpdateStatefulSetGin)
+	statefulSets.DELETE("/kubernetes/statefulsets/:namespace/:name", k8sHandler.DeleteStatefulSetGin)
 
 	// StatefulSet rollout routes
-	r.PATCH("/kubernetes/statefulsets/:namespace/:name/rollout/restart", k8sHandler.RolloutRestartStatefulSetGin)
-	r.PATCH("/kubernetes/statefulsets/:namespace/:name/rollout/undo", k8sHandler.RolloutUndoStatefulSetGin)
-	r.PATCH("/kubernetes/statefulsets/:namespace/:name/rollout/images", k8sHandler.RolloutSetImageStatefulSetGin)
+	statefulSetActions.PATCH("/kubernetes/statefulsets/:namespace/:name/rollout/restart", k8sHandler.RolloutRestartStatefulSetGin)
+	statefulSets.PATCH("/kubernetes/statefulsets/:namespace/:name/rollout/undo", k8sHandler.RolloutUndoStatefulSetGin)
+	statefulSets.PATCH("/kubernetes/statefulsets/:namespace/:name/rollout/images", k8sHandler.RolloutSetImageStatefulSetGin)
 
 	// Job routes
-	r.GET("/kubernetes/jobs", k8sHandler.ListJobsGin)
-	r.GET("/kubernetes/jobs/:namespace/:name", k8sHandler.GetJobDetailGin)
-	r.POST("/kubernetes/jobs", k8sHandler.ApplyJobGin)
-	r.PUT("/kubernetes/jobs/:namespace/:name", k8sHandler.UpdateJobGin)
-	r.DELETE("/kubernetes/jobs/:namespace/:name", k8sHandler.DeleteJobGin)
+	jobs.GET("/kubernetes/jobs", k8sHandler.ListJobsGin)
+	jobs.GET("/kubernetes/jobs/:namespace/:name", k8sHandler.GetJobDetailGin)
+	jobs.POST("/kubernetes/jobs", k8sHandler.ApplyJobGin)
+	jobs.PUT("/kubernetes/jobs/:namespace/:name", k8sHandler.UpdateJobGin)
+	jobs.DELETE("/kubernetes/jobs/:namespace/:name", k8sHandler.DeleteJobGin)
 
 	// CronJob routes
-	r.GET("/kubernetes/cronjobs", k8sHandler.ListCronJobsGin)
-	r.GET("/kubernetes/cronjobs/:namespace/:name", k8sHandler.GetCronJobDetailGin)
-	r.POST("/kubernetes/cronjobs", k8sHandler.ApplyCronJobGin)
-	r.PUT("/kubernetes/cronjobs/:namespace/:name", k8sHandler.UpdateCronJobGin)
-	r.DELETE("/kubernetes/cronjobs/:namespace/:name", k8sHandler.DeleteCronJobGin)
+	cronJobs.GET("/kubernetes/cronjobs", k8sHandler.ListCronJobsGin)
+	cronJobs.GET("/kubernetes/cronjobs/:namespace/:name", k8sHandler.GetCronJobDetailGin)
+	cronJobs.POST("/kubernetes/cronjobs", k8sHandler.ApplyCronJobGin)
+	cronJobs.PUT("/kubernetes/cronjobs/:namespace/:name", k8sHandler.UpdateCronJobGin)
+	cronJobs.DELETE("/kubernetes/cronjobs/:namespace/:name", k8sHandler.DeleteCronJobGin)
 
 	// Cluster info
-	r.GET("/kubernetes/cluster-info", k8sHandler.GetClusterInfoGin)
+	cluster.GET("/kubernetes/cluster-info", k8sHandler.GetClusterInfoGin)
 
 	// IngressClass routes
-	r.GET("/kubernetes/ingressclasses", k8sHandler.ListIngressClassesGin)
-	r.GET("/kubernetes/ingressclasses/:name", k8sHandler.GetIngressClassDetailGin)
-	r.DELETE("/kubernetes/ingressclasses/:name", k8sHandler.DeleteIngressClassGin)
-	r.POST("/kubernetes/ingressclasses", k8sHandler.ApplyIngressClassGin)
-	r.PUT("/kubernetes/ingressclasses/:name", k8sHandler.UpdateIngressClassGin)
+	ingressClasses.GET("/kubernetes/ingressclasses", k8sHandler.ListIngressClassesGin)
+	ingressClasses.GET("/kubernetes/ingressclasses/:name", k8sHandler.GetIngressClassDetailGin)
+	ingressClasses.DELETE("/kubernetes/ingressclasses/:name", k8sHandler.DeleteIngressClassGin)
+	ingressClasses.POST("/kubernetes/ingressclasses", k8sHandler.ApplyIngressClassGin)
+	ingressClasses.PUT("/kubernetes/ingressclasses/:name", k8sHandler.UpdateIngressClassGin)
 
 	// NetworkPolicy routes
-	r.GET("/kubernetes/networkpolicies", k8sHandler.ListNetworkPoliciesGin)
-	r.GET("/kubernetes/networkpolicies/:namespace/:name", k8sHandler.GetNetworkPolicyDetailGin)
-	r.DELETE("/kubernetes/networkpolicies/:namespace/:name", k8sHandler.DeleteNetworkPolicyGin)
-	r.POST("/kubernetes/networkpolicies", k8sHandler.ApplyNetworkPolicyGin)
-	r.PUT("/kubernetes/networkpolicies/:namespace/:name", k8sHandler.UpdateNetworkPolicyGin)
+	networkPolicies.GET("/kubernetes/networkpolicies", k8sHandler.ListNetworkPoliciesGin)
+	networkPolicies.GET("/kubernetes/networkpolicies/:namespace/:name", k8sHandler.GetNetworkPolicyDetailGin)
+	networkPolicies.DELETE("/kubernetes/networkpolicies/:namespace/:name", k8sHandler.DeleteNetworkPolicyGin)
+	networkPolicies.POST("/kubernetes/networkpolicies", k8sHandler.ApplyNetworkPolicyGin)
+	networkPolicies.PUT("/kubernetes/networkpolicies/:namespace/:name", k8sHandler.UpdateNetworkPolicyGin)
 
 	// Helm service
 	HelmRoutes(r, kubernetesService)
@@ -238,6 +270,7 @@ func KubernetesRoutes(r *gin.RouterGroup, eventsHub *websocket.Hub) {
 
 // HelmRoutes sets up Helm-related routes
 func HelmRoutes(r *gin.RouterGroup, kubernetesService *kubernetes.Service) {
+	r = r.Group("", auth.Authorize("kubernetes.helm"))
 	helmService, err := helm.NewService(kubernetesService)
 	if err != nil {
 		log.Printf("Warning: Helm service not available: %v", err)
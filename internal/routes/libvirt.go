@@ -43,19 +43,24 @@ func LibvirtRoutes(r *gin.RouterGroup, authService *auth.EnhancedService, servic
 
 	r.Use(authService.AuthMiddleware())
 
-	r.GET("/virtualization/capabilities", getDomainCapabilities(service))
-
-	vmGroup := r.Group("/virtualization/computes")
+	r.GET("/virtualization/capabilities", auth.Authorize("virtualization.capabilities"), getDomainCapabilities(service))
+
+	vmGroup := r.Group("/virtualization/computes", auth.Authorize("virtualization.computes"))
+	vmActions := r.Group("/virtualization/computes", auth.RequirePermission("virtualization.computes", auth.VerbAction))
+	consoleGroup := r.Group("/virtualization/computes", auth.Authorize("virtualization.consoles"))
+	snapshotGroup := r.Group("/virtualization/computes", auth.Authorize("virtualization.snapshots"))
+	backupGroup := r.Group("/virtualization/computes", auth.Authorize("virtualization.backups"))
+	templateGroup := r.Group("/virtualization/computes", auth.Authorize("virtualization.templates"))
 	{
 		// Console Access
 		// Legacy endpoint (backward compatibility) - returns all available consoles if no type specified
-		vmGroup.GET("/:id/console", getConsole(service))
+		consoleGroup.GET("/:id/console", getConsole(service))
 
 		// New improved endpoints
-		vmGroup.GET("/:id/consoles", getAvailableConsoles(service)) // Get all available console types
-		vmGroup.GET("/:id/console/vnc", getVNCConsole(service))     // Get VNC console info
-		vmGroup.GET("/:id/console/spice", getSPICEConsole(service)) // Get SPICE console info
-		vmGroup.GET("/:id/console/stats", getConsoleStats(service)) // Get console connection stats
+		consoleGroup.GET("/:id/consoles", getAvailableConsoles(service)) // Get all available console types
+		consoleGroup.GET("/:id/console/vnc", getVNCConsole(service))     // Get VNC console info
+		consoleGroup.GET("/:id/console/spice", getSPICEConsole(service)) // Get SPICE console info
+		consoleGroup.GET("/:id/console/stats", getConsoleStats(service)) // Get console connection stats
 		// VM Management
 		// vmGroup.GET("/:id", getVM(service))                                          // Get VM details
 		// vmGroup.POST("", createVM(service))                                          // Create new VM
@@ -68,25 +73,25 @@ func LibvirtRoutes(r *gin.RouterGroup, authService *auth.EnhancedService, servic
 		vmGroup.PUT("/:id", updateVMEnhanced(service)) // Update VM with enhanced options
 		vmGroup.DELETE("/:id", deleteVM(service))      // Delete VM
 
-		vmGroup.POST("/:id/action", vmAction(service)) // VM actions (start, stop, etc.)
+		vmActions.POST("/:id/action", vmAction(service)) // VM actions (start, stop, etc.)
 
 		// network
 		vmGroup.POST("/:id/network-link", setNetworkLinkState(service))                // Set network interface link state
 		vmGroup.GET("/:id/network-link/:interface-name", getNetworkLinkState(service)) // Get network interface link state
 
 		// Snapshots
-		vmGroup.GET("/:id/snapshots/capabilities", getSnapshotCapabilities(service)) // Check snapshot capabilities
-		vmGroup.GET("/:id/snapshots", listSnapshots(service))                        // List VM snapshots
-		vmGroup.POST("/:id/snapshots", createSnapshot(service))                      // Create snapshot
-		vmGroup.POST("/:id/snapshots/:snapshot/revert", revertSnapshot(service))     // Revert to snapshot
-		vmGroup.DELETE("/:id/snapshots/:snapshot", deleteSnapshot(service))          // Delete snapshot
-		vmGroup.GET("/:id/snapshots/:snapshot", getSnapshotDetail(service))          // Get snapshot details
+		snapshotGroup.GET("/:id/snapshots/capabilities", getSnapshotCapabilities(service)) // Check snapshot capabilities
+		snapshotGroup.GET("/:id/snapshots", listSnapshots(service))                        // List VM snapshots
+		snapshotGroup.POST("/:id/snapshots", createSnapshot(service))                      // Create snapshot
+		snapshotGroup.POST("/:id/snapshots/:snapshot/revert", revertSnapshot(service))     // Revert to snapshot
+		snapshotGroup.DELETE("/:id/snapshots/:snapshot", deleteSnapshot(service))          // Delete snapshot
+		snapshotGroup.GET("/:id/snapshots/:snapshot", getSnapshotDetail(service))          // Get snapshot details
 
 		// Backups
-		vmGroup.GET("/:id/backups", listBackups(service))            // List VM backups
-		vmGroup.POST("/:id/backups", createBackup(service))          // Create backup
-		vmGroup.POST("/restore", restoreBackup(service))             // Restore from backup
-		vmGroup.DELETE("/backups/:backup_id", deleteBackup(service)) // Delete backup
+		backupGroup.GET("/:id/backups", listBackups(service))            // List VM backups
+		backupGroup.POST("/:id/backups", createBackup(service))          // Create backup
+		backupGroup.POST("/restore", restoreBackup(service))             // Restore from backup
+		backupGroup.DELETE("/backups/:backup_id", deleteBackup(service)) // Delete backup
 
 		// Cloning
 		vmGroup.POST("/:id/clone", cloneVM(service)) // Clone VM
@@ -97,13 +102,13 @@ func LibvirtRoutes(r *gin.RouterGroup, authService *auth.EnhancedService, servic
 		vmGroup.GET("/:id/metrics/stream", streamVMMetrics(service)) // Stream metrics via WebSocket
 
 		// Templates
-		vmGroup.GET("/templates", listTemplates(service))             // List VM templates
-		vmGroup.GET("/templates/:id", getTemplate(service))           // Get template details
-		vmGroup.POST("/templates", createTemplate(service))           // Create new template
-		vmGroup.PUT("/templates/:id", updateTemplate(service))        // Update template
-		vmGroup.DELETE("/templates/:id", deleteTemplate(service))     // Delete template
-		vmGroup.POST("/:id/template", createTemplateFromVM(service))  // Create template from existing VM
-		vmGroup.POST("/from-template", createVMFromTemplate(service)) // Create VM from template
+		templateGroup.GET("/templates", listTemplates(service))                                                                                // List VM templates
+		templateGroup.GET("/templates/:id", getTemplate(service))                                                                              // Get template details
+		templateGroup.POST("/templates", createTemplate(service))                                                                              // Create new template
+		templateGroup.PUT("/templates/:id", updateTemplate(service))                                                                           // Update template
+		templateGroup.DELETE("/templates/:id", deleteTemplate(service))                                                                        // Delete template
+		templateGroup.POST("/:id/template", createTemplateFromVM(service))                                                                     // Create template from existing VM
+		templateGroup.POST("/from-template", auth.RequirePermission("virtualization.computes", auth.VerbWrite), createVMFromTemplate(service)) // Create VM from template
 
 		// Migration
 		vmGroup.POST("/:id/migrate", migrateVM(service))                  // Migrate VM to another host
@@ -117,13 +122,13 @@ func LibvirtRoutes(r *gin.RouterGroup, authService *auth.EnhancedService, servic
 	}
 
 	// Global Backups
-	r.GET("/virtualization/computes/backups", listAllBackups(service))
-	r.POST("/virtualization/computes/backups/import", importBackup(service))
-	r.GET("/virtualization/computes/backups/:backup_id", getBackup(service))
-	r.GET("/virtualization/computes/backups/:backup_id/download", downloadBackup(service))
+	backupGroup.GET("/backups", listAllBackups(service))
+	backupGroup.POST("/backups/import", importBackup(service))
+	backupGroup.GET("/backups/:backup_id", getBackup(service))
+	backupGroup.GET("/backups/:backup_id/download", downloadBackup(service))
 
 	// Backup Upload with resumable uploads (TUS protocol)
-	backupUpload := r.Group("/virtualization/computes/backups/upload")
+	backupUpload := r.Group("/virtualization/computes/backups/upload", auth.Authorize("virtualization.backups"))
 	{
 		// Setup resumable upload handler for VM backups using TUS protocol
 		backupUploadDir := filepath.Join(os.TempDir(), "vapor-uploads", "backups")
@@ -142,7 +147,7 @@ func LibvirtRoutes(r *gin.RouterGroup, authService *auth.EnhancedService, servic
 	}
 
 	// ISO Management
-	isoGroup := r.Group("/virtualization/isos")
+	isoGroup := r.Group("/virtualization/isos", auth.Authorize("virtualization.isos"))
 	{
 		isoGroup.GET("", listISOs(service))
 		isoGroup.POST("", uploadISO(service))
@@ -172,7 +177,7 @@ func LibvirtRoutes(r *gin.RouterGroup, authService *auth.EnhancedService, servic
 	}
 
 	// Storage Management
-	storageGroup := r.Group("/virtualization/storages")
+	storageGroup := r.Group("/virtualization/storages", auth.Authorize("virtualization.storages"))
 	{
 		poolsGroup := storageGroup.Group("/pools")
 		{
@@ -199,10 +204,10 @@ func LibvirtRoutes(r *gin.RouterGroup, authService *auth.EnhancedService, servic
 	}
 
 	// Volumes (across all pools)
-	r.GET("/virtualization/volumes", listAllVolumes(service)) // List all volumes across all pools
+	r.GET("/virtualization/volumes", auth.Authorize("virtualization.storages"), listAllVolumes(service)) // List all volumes across all pools
 
 	// Volume Upload with resumable uploads (TUS protocol)
-	volumeUpload := r.Group("/virtualization/volumes/upload")
+	volumeUpload := r.Group("/virtualization/volumes/upload", auth.Authorize("virtualization.storages"))
 	{
 		uploadDir := filepath.Join(os.TempDir(), "vapor-uploads", "volumes")
 		volumeUploadHandler := libvirt.NewVolumeResumableUploadHandler(service, uploadDir)
@@ -219,7 +224,7 @@ func LibvirtRoutes(r *gin.RouterGroup, authService *auth.EnhancedService, servic
 	}
 
 	// Network Management
-	networkGroup := r.Group("/virtualization/networks")
+	networkGroup := r.Group("/virtualization/networks", auth.Authorize("virtualization.networks"))
 	{
 		networkGroup.GET("", listNetworks(service))                           // List virtual networks
 		networkGroup.GET("/:name", getNetwork(service))                       // Get network details
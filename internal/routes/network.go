@@ -1,31 +1,37 @@
 package routes
 
 import (
+	"github.com/awanio/vapor/internal/auth"
 	"github.com/awanio/vapor/internal/network"
 	"github.com/gin-gonic/gin"
 )
 
 // NetworkRoutes sets up network-related routes
 func NetworkRoutes(r *gin.RouterGroup, networkService *network.Service) {
+	interfaces := r.Group("", auth.Authorize("network.interfaces"))
+	bridges := r.Group("", auth.Authorize("network.bridges"))
+	bonds := r.Group("", auth.Authorize("network.bonds"))
+	vlans := r.Group("", auth.Authorize("network.vlans"))
+
 	// Network endpoints
-	r.GET("/network/interface-types", networkService.GetInterfaceTypes)
-	r.GET("/network/interfaces", networkService.GetInterfaces)
-	r.PUT("/network/interfaces/:name/up", networkService.InterfaceUp)
-	r.PUT("/network/interfaces/:name/down", networkService.InterfaceDown)
-	r.POST("/network/interfaces/:name/address", networkService.SetInterfaceAddress)
-	r.PUT("/network/interfaces/:name/address", networkService.UpdateInterfaceAddress)
-	r.DELETE("/network/interfaces/:name/address", networkService.DeleteInterfaceAddress)
-	r.GET("/network/bridges", networkService.GetBridges)
-	r.GET("/network/bridge/:name", networkService.GetBridge)
-	r.POST("/network/bridge", networkService.CreateBridge)
-	r.PUT("/network/bridge/:name", networkService.UpdateBridge)
-	r.DELETE("/network/bridge/:name", networkService.DeleteBridge)
-	r.GET("/network/bonds", networkService.GetBonds)
-	r.POST("/network/bond", networkService.CreateBond)
-	r.PUT("/network/bond/:name", networkService.UpdateBond)
-	r.DELETE("/network/bond/:name", networkService.DeleteBond)
-	r.GET("/network/vlans", networkService.GetVLANs)
-	r.POST("/network/vlan", networkService.CreateVLAN)
-	r.PUT("/network/vlan/:name", networkService.UpdateVLAN)
-	r.DELETE("/network/vlan/:name", networkService.DeleteVLAN)
+	interfaces.GET("/network/interface-types", networkService.GetInterfaceTypes)
+	interfaces.GET("/network/interfaces", networkService.GetInterfaces)
+	interfaces.PUT("/network/interfaces/:name/up", networkService.InterfaceUp)
+	interfaces.PUT("/network/interfaces/:name/down", networkService.InterfaceDown)
+	interfaces.POST("/network/interfaces/:name/address", networkService.SetInterfaceAddress)
+	interfaces.PUT("/network/interfaces/:name/address", networkService.UpdateInterfaceAddress)
+	interfaces.DELETE("/network/interfaces/:name/address", networkService.DeleteInterfaceAddress)
+	bridges.GET("/network/bridges", networkService.GetBridges)
+	bridges.GET("/network/bridge/:name", networkService.GetBridge)
+	bridges.POST("/network/bridge", networkService.CreateBridge)
+	bridges.PUT("/network/bridge/:name", networkService.UpdateBridge)
+	bridges.DELETE("/network/bridge/:name", networkService.DeleteBridge)
+	bonds.GET("/network/bonds", networkService.GetBonds)
+	bonds.POST("/network/bond", networkService.CreateBond)
+	bonds.PUT("/network/bond/:name", networkService.UpdateBond)
+	bonds.DELETE("/network/bond/:name", networkService.DeleteBond)
+	vlans.GET("/network/vlans", networkService.GetVLANs)
+	vlans.POST("/network/vlan", networkService.CreateVLAN)
+	vlans.PUT("/network/vlan/:name", networkService.UpdateVLAN)
+	vlans.DELETE("/network/vlan/:name", networkService.DeleteVLAN)
 }
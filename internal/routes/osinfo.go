@@ -38,7 +38,7 @@ var (
 func OSInfoRoutes(r *gin.RouterGroup, authService *auth.EnhancedService) {
 	virtualization := r.Group("/virtualization")
 	if authService != nil {
-		virtualization.Use(authService.AuthMiddleware())
+		virtualization.Use(authService.AuthMiddleware(), auth.Authorize("virtualization.osinfo"))
 	}
 	virtualization.GET("/os-variants", listOSVariants())
 }
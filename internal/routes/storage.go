@@ -1,44 +1,52 @@
 package routes
 
 import (
+	"github.com/awanio/vapor/internal/auth"
 	"github.com/awanio/vapor/internal/storage"
 	"github.com/gin-gonic/gin"
 )
 
 // StorageRoutes sets up storage-related routes
 func StorageRoutes(r *gin.RouterGroup, storageService *storage.Service) {
+	disks := r.Group("", auth.Authorize("storage.disks"))
+	lvm := r.Group("", auth.Authorize("storage.lvm"))
+	iscsi := r.Group("", auth.Authorize("storage.iscsi"))
+	multipath := r.Group("", auth.Authorize("storage.multipath"))
+	btrfs := r.Group("", auth.Authorize("storage.btrfs"))
+	raid := r.Group("", auth.Authorize("storage.raid"))
+
 	// Storage endpoints
-	r.GET("/storage/disks", storageService.GetDisks)
-	r.POST("/storage/mount", storageService.Mount)
-	r.POST("/storage/unmount", storageService.Unmount)
-	r.POST("/storage/format", storageService.Format)
+	disks.GET("/storage/disks", storageService.GetDisks)
+	disks.POST("/storage/mount", storageService.Mount)
+	disks.POST("/storage/unmount", storageService.Unmount)
+	disks.POST("/storage/format", storageService.Format)
 
 	// LVM endpoints
-	r.GET("/storage/lvm/vgs", storageService.GetVolumeGroups)
-	r.GET("/storage/lvm/lvs", storageService.GetLogicalVolumes)
-	r.GET("/storage/lvm/pvs", storageService.GetPhysicalVolumes)
-	r.POST("/storage/lvm/vg", storageService.CreateVolumeGroup)
-	r.POST("/storage/lvm/lv", storageService.CreateLogicalVolume)
+	lvm.GET("/storage/lvm/vgs", storageService.GetVolumeGroups)
+	lvm.GET("/storage/lvm/lvs", storageService.GetLogicalVolumes)
+	lvm.GET("/storage/lvm/pvs", storageService.GetPhysicalVolumes)
+	lvm.POST("/storage/lvm/vg", storageService.CreateVolumeGroup)
+	lvm.POST("/storage/lvm/lv", storageService.CreateLogicalVolume)
 
 	// iSCSI endpoints
-	r.POST("/storage/iscsi/discover", storageService.DiscoverISCSITargets)
-	r.GET("/storage/iscsi/sessions", storageService.GetISCSISessions)
-	r.POST("/storage/iscsi/login", storageService.LoginISCSI)
-	r.POST("/storage/iscsi/logout", storageService.LogoutISCSI)
+	iscsi.POST("/storage/iscsi/discover", storageService.DiscoverISCSITargets)
+	iscsi.GET("/storage/iscsi/sessions", storageService.GetISCSISessions)
+	iscsi.POST("/storage/iscsi/login", storageService.LoginISCSI)
+	iscsi.POST("/storage/iscsi/logout", storageService.LogoutISCSI)
 
 	// Multipath endpoints
-	r.GET("/storage/multipath/devices", storageService.GetMultipathDevices)
-	r.GET("/storage/multipath/paths", storageService.GetMultipathPaths)
+	multipath.GET("/storage/multipath/devices", storageService.GetMultipathDevices)
+	multipath.GET("/storage/multipath/paths", storageService.GetMultipathPaths)
 
 	// BTRFS endpoints
-	r.GET("/storage/btrfs/subvolumes", storageService.GetBTRFSSubvolumes)
-	r.POST("/storage/btrfs/subvolume", storageService.CreateBTRFSSubvolume)
-	r.DELETE("/storage/btrfs/subvolume", storageService.DeleteBTRFSSubvolume)
-	r.POST("/storage/btrfs/snapshot", storageService.CreateBTRFSSnapshot)
+	btrfs.GET("/storage/btrfs/subvolumes", storageService.GetBTRFSSubvolumes)
+	btrfs.POST("/storage/btrfs/subvolume", storageService.CreateBTRFSSubvolume)
+	btrfs.DELETE("/storage/btrfs/subvolume", storageService.DeleteBTRFSSubvolume)
+	btrfs.POST("/storage/btrfs/snapshot", storageService.CreateBTRFSSnapshot)
 
 	// RAID endpoints
-	r.GET("/storage/raid/devices", storageService.GetRAIDDevices)
-	r.GET("/storage/raid/available-disks", storageService.GetRAIDAvailableDisks)
-	r.POST("/storage/raid/create", storageService.CreateRAIDDevice)
-	r.DELETE("/storage/raid/destroy", storageService.DestroyRAIDDevice)
+	raid.GET("/storage/raid/devices", storageService.GetRAIDDevices)
+	raid.GET("/storage/raid/available-disks", storageService.GetRAIDAvailableDisks)
+	raid.POST("/storage/raid/create", storageService.CreateRAIDDevice)
+	raid.DELETE("/storage/raid/destroy", storageService.DestroyRAIDDevice)
 }
@@ -1,12 +1,14 @@
 package routes
 
 import (
+	"github.com/awanio/vapor/internal/auth"
 	"github.com/awanio/vapor/internal/system"
 	"github.com/gin-gonic/gin"
 )
 
 // SystemRoutes sets up system information routes
 func SystemRoutes(r *gin.RouterGroup, systemService *system.Service) {
+	r = r.Group("", auth.Authorize("system"))
 	r.GET("/system/summary", systemService.GetSummary)
 	r.GET("/system/hardware", systemService.GetHardware)
 	r.GET("/system/memory", systemService.GetMemory)
@@ -1,12 +1,14 @@
 package routes
 
 import (
+	"github.com/awanio/vapor/internal/auth"
 	"github.com/awanio/vapor/internal/users"
 	"github.com/gin-gonic/gin"
 )
 
 // UserRoutes sets up user management routes
 func UserRoutes(r *gin.RouterGroup, userService *users.Service) {
+	r = r.Group("", auth.Authorize("users"))
 	r.GET("/users", userService.GetUsers)
 	r.POST("/users", userService.CreateUser)
 	r.PUT("/users/:username", userService.UpdateUser)
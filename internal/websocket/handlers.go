@@ -134,6 +134,12 @@ func handleAuth(client *Client, msg Message, jwtSecret string) {
 	if !authenticated && client.tokenService != nil {
 		apiToken, err := client.tokenService.VerifyToken(tokenStr)
 		if err == nil && apiToken != nil {
+			// Scoped tokens are meant for automation and may only follow
+			// event streams, never open interactive sessions
+			if apiToken.IsScoped() && client.handlerType != "events" {
+				client.sendError("API token scope does not permit interactive sessions")
+				return
+			}
 			username = apiToken.Username
 			authenticated = true
 		}